@@ -0,0 +1,322 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diff computes the structural differences between two Score workloads or two framework states. Each
+// difference is reported as a Change with the path of the value that was added, removed, or changed.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/score-spec/score-go/framework"
+	"github.com/score-spec/score-go/types"
+)
+
+// Operation describes how a value differs between the old and new inputs.
+type Operation string
+
+const (
+	Added   Operation = "added"
+	Removed Operation = "removed"
+	Changed Operation = "changed"
+)
+
+// Change is a single difference between two inputs. Old is unset for added values and New is unset for removed
+// values. Values are in their decoded json form.
+type Change struct {
+	Path      []string    `json:"path" yaml:"path"`
+	Operation Operation   `json:"operation" yaml:"operation"`
+	Old       interface{} `json:"old,omitempty" yaml:"old,omitempty"`
+	New       interface{} `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+// PathString returns the path of the change as a dot-separated string. Dots within path parts are escaped with a
+// backslash so that the result can be parsed with framework.ParseDotPathParts.
+func (c Change) PathString() string {
+	parts := make([]string, len(c.Path))
+	for i, p := range c.Path {
+		p = strings.ReplaceAll(p, "\\", "\\\\")
+		parts[i] = strings.ReplaceAll(p, ".", "\\.")
+	}
+	return strings.Join(parts, ".")
+}
+
+// String returns a human readable description of the change such as "container api: image changed from nginx:1.2 to
+// nginx:1.3".
+func (c Change) String() string {
+	subject, rest := describeSubject(c.Path)
+	var sb strings.Builder
+	if subject != "" {
+		sb.WriteString(subject)
+		if len(rest) == 0 {
+			sb.WriteString(" ")
+		} else {
+			sb.WriteString(": ")
+		}
+	}
+	sb.WriteString(strings.Join(rest, "."))
+	if len(rest) > 0 {
+		sb.WriteString(" ")
+	}
+	switch c.Operation {
+	case Added:
+		sb.WriteString("added")
+		if len(rest) > 0 || isScalar(c.New) {
+			sb.WriteString(" with value " + formatValue(c.New))
+		}
+	case Removed:
+		sb.WriteString("removed")
+		if len(rest) > 0 || isScalar(c.Old) {
+			sb.WriteString(" (was " + formatValue(c.Old) + ")")
+		}
+	default:
+		sb.WriteString("changed from " + formatValue(c.Old) + " to " + formatValue(c.New))
+	}
+	return sb.String()
+}
+
+// describeSubject splits the path into a readable subject such as "container api" and the remaining path within it.
+func describeSubject(path []string) (string, []string) {
+	if len(path) < 2 {
+		return "", path
+	}
+	switch path[0] {
+	case "workloads":
+		subject, rest := describeSubject(path[2:])
+		if subject == "" {
+			return "workload " + path[1], rest
+		}
+		return "workload " + path[1] + ": " + subject, rest
+	case "containers":
+		return "container " + path[1], path[2:]
+	case "resources":
+		return "resource " + path[1], path[2:]
+	case "metadata":
+		return "metadata", path[1:]
+	case "service":
+		if path[1] == "ports" && len(path) > 2 {
+			return "service port " + path[2], path[3:]
+		}
+	}
+	return "", path
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	return true
+}
+
+func formatValue(v interface{}) string {
+	switch typed := v.(type) {
+	case string:
+		if typed == "" {
+			return `""`
+		}
+		return typed
+	case nil:
+		return "null"
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(raw)
+}
+
+// Workloads returns the changes between two workloads. A nil workload is treated as an empty one. Nil and empty maps or
+// lists are considered equal. The changes are sorted by path.
+func Workloads(old, new *types.Workload) ([]Change, error) {
+	oldGeneric, err := toGeneric(old)
+	if err != nil {
+		return nil, fmt.Errorf("old workload: %w", err)
+	}
+	newGeneric, err := toGeneric(new)
+	if err != nil {
+		return nil, fmt.Errorf("new workload: %w", err)
+	}
+	out := make([]Change, 0)
+	diffValues(nil, oldGeneric, newGeneric, &out)
+	return out, nil
+}
+
+// States returns the changes between the workloads and resources of two states. Workloads are compared by their spec
+// and resources by their type, class, id, metadata, params, source workload, and provisioner. Resource outputs and
+// internal state are not compared since they may contain secrets. A nil state is treated as an empty one.
+func States[StateExtras, WorkloadExtras, ResourceExtras any](old, new *framework.State[StateExtras, WorkloadExtras, ResourceExtras]) ([]Change, error) {
+	oldGeneric, err := stateToGeneric(old)
+	if err != nil {
+		return nil, fmt.Errorf("old state: %w", err)
+	}
+	newGeneric, err := stateToGeneric(new)
+	if err != nil {
+		return nil, fmt.Errorf("new state: %w", err)
+	}
+	out := make([]Change, 0)
+	diffValues(nil, oldGeneric, newGeneric, &out)
+	return out, nil
+}
+
+func stateToGeneric[StateExtras, WorkloadExtras, ResourceExtras any](s *framework.State[StateExtras, WorkloadExtras, ResourceExtras]) (map[string]interface{}, error) {
+	workloads := make(map[string]interface{})
+	resources := make(map[string]interface{})
+	if s != nil {
+		for name, w := range s.Workloads {
+			spec := w.Spec
+			generic, err := toGeneric(&spec)
+			if err != nil {
+				return nil, fmt.Errorf("workloads: %s: %w", name, err)
+			}
+			workloads[name] = generic
+		}
+		for uid, r := range s.Resources {
+			generic, err := roundTrip(map[string]interface{}{
+				"type":           r.Type,
+				"class":          r.Class,
+				"id":             r.Id,
+				"metadata":       r.Metadata,
+				"params":         r.Params,
+				"sourceWorkload": r.SourceWorkload,
+				"provisioner":    r.ProvisionerUri,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("resources: %s: %w", uid, err)
+			}
+			resources[string(uid)] = generic
+		}
+	}
+	return map[string]interface{}{"workloads": workloads, "resources": resources}, nil
+}
+
+func toGeneric(workload *types.Workload) (map[string]interface{}, error) {
+	if workload == nil {
+		return map[string]interface{}{}, nil
+	}
+	return roundTrip(workload)
+}
+
+// roundTrip converts the input to the generic map structure produced by decoding json.
+func roundTrip(input interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode: %w", err)
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode: %w", err)
+	}
+	return out, nil
+}
+
+// isEmpty returns true for values that are equivalent to an absent value.
+func isEmpty(v interface{}) bool {
+	switch typed := v.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(typed) == 0
+	case []interface{}:
+		return len(typed) == 0
+	}
+	return false
+}
+
+func diffValues(path []string, old, new interface{}, out *[]Change) {
+	if isEmpty(old) && isEmpty(new) {
+		return
+	}
+	if (isEmpty(old) || isEmpty(new)) && expandsWhenEmpty(path) && isMapOrEmpty(old) && isMapOrEmpty(new) {
+		// report the entries of top level collections individually so that a new workload spec reads as a list of
+		// added containers and resources rather than a single change
+		old, new = nonNilMap(old), nonNilMap(new)
+	} else if isEmpty(old) {
+		*out = append(*out, Change{Path: path, Operation: Added, New: new})
+		return
+	} else if isEmpty(new) {
+		*out = append(*out, Change{Path: path, Operation: Removed, Old: old})
+		return
+	}
+
+	switch oldTyped := old.(type) {
+	case map[string]interface{}:
+		if newTyped, ok := new.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(oldTyped)+len(newTyped))
+			for k := range oldTyped {
+				keys = append(keys, k)
+			}
+			for k := range newTyped {
+				if _, ok := oldTyped[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				diffValues(appendPath(path, k), oldTyped[k], newTyped[k], out)
+			}
+			return
+		}
+	case []interface{}:
+		if newTyped, ok := new.([]interface{}); ok {
+			for i := 0; i < len(oldTyped) || i < len(newTyped); i++ {
+				p := appendPath(path, strconv.Itoa(i))
+				if i >= len(oldTyped) {
+					*out = append(*out, Change{Path: p, Operation: Added, New: newTyped[i]})
+				} else if i >= len(newTyped) {
+					*out = append(*out, Change{Path: p, Operation: Removed, Old: oldTyped[i]})
+				} else {
+					diffValues(p, oldTyped[i], newTyped[i], out)
+				}
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(old, new) {
+		*out = append(*out, Change{Path: path, Operation: Changed, Old: old, New: new})
+	}
+}
+
+// expandsWhenEmpty returns true if the path is the root, a top level collection such as containers or resources, or the
+// service ports. Paths within a state workload are considered relative to the workload spec.
+func expandsWhenEmpty(path []string) bool {
+	if len(path) > 2 && path[0] == "workloads" {
+		path = path[2:]
+	}
+	return len(path) <= 1 || (len(path) == 2 && path[0] == "service" && path[1] == "ports")
+}
+
+func isMapOrEmpty(v interface{}) bool {
+	_, ok := v.(map[string]interface{})
+	return ok || isEmpty(v)
+}
+
+func nonNilMap(v interface{}) map[string]interface{} {
+	if m, ok := v.(map[string]interface{}); ok {
+		return m
+	}
+	return map[string]interface{}{}
+}
+
+// appendPath returns a new path so that sibling changes never share a backing array.
+func appendPath(path []string, part string) []string {
+	out := make([]string, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, part)
+}
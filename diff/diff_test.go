@@ -0,0 +1,225 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/score-spec/score-go/framework"
+	"github.com/score-spec/score-go/types"
+)
+
+func mustLoadWorkload(t *testing.T, spec string) *types.Workload {
+	t.Helper()
+	var out types.Workload
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &out))
+	return &out
+}
+
+func changeStrings(changes []Change) []string {
+	out := make([]string, len(changes))
+	for i, c := range changes {
+		out[i] = c.String()
+	}
+	return out
+}
+
+func TestWorkloads(t *testing.T) {
+	old := mustLoadWorkload(t, `
+apiVersion: score.dev/v1b1
+metadata:
+  name: example
+containers:
+  api:
+    image: nginx:1.2
+    args: ["a", "b"]
+    variables:
+      A: "1"
+      B: "2"
+  worker:
+    image: busybox
+resources:
+  db:
+    type: postgres
+    class: small
+    params:
+      size: 1
+`)
+	new := mustLoadWorkload(t, `
+apiVersion: score.dev/v1b1
+metadata:
+  name: example
+  annotations: {}
+containers:
+  api:
+    image: nginx:1.3
+    args: ["a"]
+    variables:
+      A: "1"
+      C: "3"
+  sidecar:
+    image: envoy
+resources:
+  db:
+    type: postgres
+    class: large
+    params:
+      size: 2
+service:
+  ports:
+    web:
+      port: 80
+`)
+
+	changes, err := Workloads(old, new)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"container api: args.1 removed (was b)",
+		"container api: image changed from nginx:1.2 to nginx:1.3",
+		"container api: variables.B removed (was 2)",
+		"container api: variables.C added with value 3",
+		"container sidecar added",
+		"container worker removed",
+		"resource db: class changed from small to large",
+		"resource db: params.size changed from 1 to 2",
+		"service port web added",
+	}, changeStrings(changes))
+
+	assert.Equal(t, Change{
+		Path: []string{"containers", "api", "image"}, Operation: Changed, Old: "nginx:1.2", New: "nginx:1.3",
+	}, changes[1])
+
+	t.Run("no changes", func(t *testing.T) {
+		changes, err := Workloads(old, old.DeepCopy())
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("nil and empty are equal", func(t *testing.T) {
+		changes, err := Workloads(&types.Workload{Containers: types.WorkloadContainers{}}, &types.Workload{Resources: types.WorkloadResources{}})
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("nil workload", func(t *testing.T) {
+		changes, err := Workloads(nil, &types.Workload{ApiVersion: "score.dev/v1b1"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"apiVersion added with value score.dev/v1b1"}, changeStrings(changes))
+	})
+
+	t.Run("service port", func(t *testing.T) {
+		a := &types.Workload{Service: &types.WorkloadService{Ports: types.WorkloadServicePorts{"web": {Port: 80}}}}
+		b := &types.Workload{Service: &types.WorkloadService{Ports: types.WorkloadServicePorts{"web": {Port: 8080}}}}
+		changes, err := Workloads(a, b)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"service port web: port changed from 80 to 8080"}, changeStrings(changes))
+	})
+}
+
+func TestChange_PathString(t *testing.T) {
+	c := Change{Path: []string{"metadata", "annotations", "acme.org/x"}}
+	assert.Equal(t, `metadata.annotations.acme\.org/x`, c.PathString())
+	assert.Equal(t, c.Path, framework.ParseDotPathParts(c.PathString()))
+}
+
+func TestStates(t *testing.T) {
+	start := new(framework.State[framework.NoExtras, framework.NoExtras, framework.NoExtras])
+	old, err := start.WithWorkload(mustLoadWorkload(t, `
+metadata:
+  name: example
+containers:
+  api:
+    image: nginx:1.2
+resources:
+  db:
+    type: postgres
+`), nil, framework.NoExtras{})
+	require.NoError(t, err)
+	old, err = old.WithPrimedResources()
+	require.NoError(t, err)
+
+	new, err := start.WithWorkload(mustLoadWorkload(t, `
+metadata:
+  name: example
+containers:
+  api:
+    image: nginx:1.3
+resources:
+  db:
+    type: postgres
+    params:
+      size: 2
+`), nil, framework.NoExtras{})
+	require.NoError(t, err)
+	new, err = new.WithPrimedResources()
+	require.NoError(t, err)
+	res := new.Resources["postgres.default#example.db"]
+	res.Outputs = map[string]interface{}{"password": "secret"}
+	new.Resources["postgres.default#example.db"] = res
+
+	changes, err := States(old, new)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"resource postgres.default#example.db: params added with value {\"size\":2}",
+		"workload example: container api: image changed from nginx:1.2 to nginx:1.3",
+		"workload example: resource db: params added with value {\"size\":2}",
+	}, changeStrings(changes))
+
+	changes, err = States(nil, old)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"resource postgres.default#example.db added",
+		"workload example added",
+	}, changeStrings(changes))
+}
+
+func TestNewOutputFormatter(t *testing.T) {
+	changes := []Change{
+		{Path: []string{"containers", "api", "image"}, Operation: Changed, Old: "nginx:1.2", New: "nginx:1.3"},
+		{Path: []string{"containers", "worker"}, Operation: Removed, Old: map[string]interface{}{"image": "busybox"}},
+	}
+
+	buff := new(bytes.Buffer)
+	f, err := NewOutputFormatter("text", changes, buff)
+	require.NoError(t, err)
+	require.NoError(t, f.Display())
+	assert.Equal(t, "container api: image changed from nginx:1.2 to nginx:1.3\ncontainer worker removed\n", buff.String())
+
+	buff.Reset()
+	f, err = NewOutputFormatter("text", nil, buff)
+	require.NoError(t, err)
+	require.NoError(t, f.Display())
+	assert.Equal(t, "no changes\n", buff.String())
+
+	buff.Reset()
+	f, err = NewOutputFormatter("json", changes[:1], buff)
+	require.NoError(t, err)
+	require.NoError(t, f.Display())
+	assert.JSONEq(t, `[{"path":["containers","api","image"],"operation":"changed","old":"nginx:1.2","new":"nginx:1.3"}]`, buff.String())
+
+	buff.Reset()
+	f, err = NewOutputFormatter("yaml", changes[1:], buff)
+	require.NoError(t, err)
+	require.NoError(t, f.Display())
+	assert.Contains(t, buff.String(), "operation: removed")
+
+	_, err = NewOutputFormatter("xml", changes, buff)
+	assert.EqualError(t, err, "unsupported output format 'xml'")
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/score-spec/score-go/formatter"
+)
+
+// TextOutputFormatter writes one human readable line per change.
+type TextOutputFormatter struct {
+	Changes []Change
+	Out     io.Writer
+}
+
+func (t *TextOutputFormatter) Display() error {
+	// Default to stdout if no output is provided
+	if t.Out == nil {
+		t.Out = os.Stdout
+	}
+	if len(t.Changes) == 0 {
+		_, err := fmt.Fprintln(t.Out, "no changes")
+		return err
+	}
+	for _, c := range t.Changes {
+		if _, err := fmt.Fprintln(t.Out, c.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewOutputFormatter returns a formatter for the changes in the given format: "text", "json", or "yaml".
+func NewOutputFormatter(format string, changes []Change, out io.Writer) (formatter.OutputFormatter, error) {
+	switch format {
+	case "text":
+		return &TextOutputFormatter{Changes: changes, Out: out}, nil
+	case "json":
+		return &formatter.JSONOutputFormatter[[]Change]{Data: changes, Out: out}, nil
+	case "yaml":
+		return &formatter.YAMLOutputFormatter[[]Change]{Data: changes, Out: out}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format '%s'", format)
+	}
+}
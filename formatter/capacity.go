@@ -0,0 +1,84 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/score-spec/score-go/types"
+)
+
+// NewCapacityReportFormatter returns a table formatter listing the cpu and memory requests and limits of every
+// container in the given workloads. Each workload is followed by a row with its totals and the table ends with a row
+// containing the totals across all workloads. Unset container values are shown as "-", and total limits which include a
+// container without that limit are shown as "unbounded".
+func NewCapacityReportFormatter(workloads map[string]*types.Workload, out io.Writer) (*TableOutputFormatter, error) {
+	names := make([]string, 0, len(workloads))
+	for name := range workloads {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([][]string, 0)
+	var total types.ResourceTotals
+	for _, workloadName := range names {
+		workloadTotal, perContainer, err := types.WorkloadResourceTotals(workloads[workloadName])
+		if err != nil {
+			return nil, fmt.Errorf("workload '%s': %w", workloadName, err)
+		}
+		containerNames := make([]string, 0, len(perContainer))
+		for name := range perContainer {
+			containerNames = append(containerNames, name)
+		}
+		sort.Strings(containerNames)
+		for _, containerName := range containerNames {
+			rows = append(rows, capacityRow(workloadName, containerName, perContainer[containerName], false))
+		}
+		rows = append(rows, capacityRow(workloadName, "(total)", workloadTotal, true))
+		total = total.Add(workloadTotal)
+	}
+	rows = append(rows, capacityRow("(total)", "", total, true))
+
+	return &TableOutputFormatter{
+		Headers: []string{"Workload", "Container", "CPU Requests", "CPU Limits", "Memory Requests", "Memory Limits"},
+		Rows:    rows,
+		Out:     out,
+	}, nil
+}
+
+// capacityRow formats a single row of the capacity report. Zero values are shown as "-" for containers and "0" for
+// totals, and unbounded limits are shown as "-" for containers and "unbounded" for totals.
+func capacityRow(workloadName, containerName string, t types.ResourceTotals, isTotal bool) []string {
+	zero, unbounded := "-", "-"
+	if isTotal {
+		zero, unbounded = "0", "unbounded"
+	}
+	row := []string{workloadName, containerName}
+	for _, c := range []struct {
+		q         types.Quantity
+		unbounded bool
+	}{{t.RequestsCpu, false}, {t.LimitsCpu, t.LimitsCpuUnbounded}, {t.RequestsMemory, false}, {t.LimitsMemory, t.LimitsMemoryUnbounded}} {
+		if c.unbounded {
+			row = append(row, unbounded)
+		} else if c.q.IsZero() {
+			row = append(row, zero)
+		} else {
+			row = append(row, c.q.String())
+		}
+	}
+	return row
+}
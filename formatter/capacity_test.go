@@ -0,0 +1,84 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/score-spec/score-go/types"
+)
+
+func TestCapacityReportFormatter(t *testing.T) {
+	ref := func(s string) *string {
+		return &s
+	}
+	workloads := map[string]*types.Workload{
+		"web": {
+			Containers: types.WorkloadContainers{
+				"api": {Image: "api", Resources: &types.ContainerResources{
+					Requests: &types.ResourcesLimits{Cpu: ref("250m"), Memory: ref("512Mi")},
+					Limits:   &types.ResourcesLimits{Cpu: ref("1"), Memory: ref("1Gi")},
+				}},
+				"sidecar": {Image: "sidecar", Resources: &types.ContainerResources{
+					Requests: &types.ResourcesLimits{Cpu: ref("0.1"), Memory: ref("64Mi")},
+				}},
+			},
+		},
+		"db": {
+			Containers: types.WorkloadContainers{
+				"postgres": {Image: "postgres"},
+			},
+		},
+	}
+	buf := &bytes.Buffer{}
+	f, err := NewCapacityReportFormatter(workloads, buf)
+	require.NoError(t, err)
+	require.NoError(t, f.Display())
+	assert.Equal(t, `+----------+-----------+--------------+------------+-----------------+---------------+
+| WORKLOAD | CONTAINER | CPU REQUESTS | CPU LIMITS | MEMORY REQUESTS | MEMORY LIMITS |
++----------+-----------+--------------+------------+-----------------+---------------+
+| db       | postgres  | -            | -          | -               | -             |
++----------+-----------+--------------+------------+-----------------+---------------+
+| db       | (total)   | 0            | unbounded  | 0               | unbounded     |
++----------+-----------+--------------+------------+-----------------+---------------+
+| web      | api       | 250m         | 1          | 512Mi           | 1Gi           |
++----------+-----------+--------------+------------+-----------------+---------------+
+| web      | sidecar   | 100m         | -          | 64Mi            | -             |
++----------+-----------+--------------+------------+-----------------+---------------+
+| web      | (total)   | 350m         | unbounded  | 576Mi           | unbounded     |
++----------+-----------+--------------+------------+-----------------+---------------+
+| (total)  |           | 350m         | unbounded  | 576Mi           | unbounded     |
++----------+-----------+--------------+------------+-----------------+---------------+
+`, buf.String())
+
+	// totals are bounded when every container sets the limit
+	workloads = map[string]*types.Workload{"web": {Containers: types.WorkloadContainers{"api": workloads["web"].Containers["api"]}}}
+	buf.Reset()
+	f, err = NewCapacityReportFormatter(workloads, buf)
+	require.NoError(t, err)
+	require.NoError(t, f.Display())
+	assert.Contains(t, buf.String(), "| (total)  |           | 250m         | 1          | 512Mi           | 1Gi           |")
+
+	workloads["db"] = &types.Workload{Containers: types.WorkloadContainers{}}
+	workloads["db"].Containers["postgres"] = types.Container{Image: "postgres", Resources: &types.ContainerResources{
+		Limits: &types.ResourcesLimits{Memory: ref("10 Gi")},
+	}}
+	_, err = NewCapacityReportFormatter(workloads, buf)
+	assert.EqualError(t, err, "workload 'db': containers: postgres: resources: limits: memory: quantity '10 Gi' has an unknown unit ' Gi'")
+}
@@ -0,0 +1,344 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framework
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	score "github.com/score-spec/score-go/types"
+)
+
+// PlanAction is the action that a Plan will take for a workload or resource.
+type PlanAction string
+
+const (
+	// PlanCreate adds a workload or resource that does not exist in the current state.
+	PlanCreate PlanAction = "create"
+	// PlanUpdate modifies the spec of a workload or the params or metadata of a resource in place.
+	PlanUpdate PlanAction = "update"
+	// PlanReplace removes a resource and creates a new one because its type or class changed.
+	PlanReplace PlanAction = "replace"
+	// PlanDelete removes a workload or a resource that is no longer referenced by any workload.
+	PlanDelete PlanAction = "delete"
+)
+
+// PlanWorkload is a workload spec passed to State.Plan.
+type PlanWorkload[WorkloadExtras any] struct {
+	Spec   *score.Workload
+	File   *string
+	Extras WorkloadExtras
+}
+
+// PlannedWorkloadChange describes a workload that will be created, updated, or deleted.
+type PlannedWorkloadChange struct {
+	Action  PlanAction `json:"action" yaml:"action"`
+	Name    string     `json:"name" yaml:"name"`
+	Reasons []string   `json:"reasons" yaml:"reasons"`
+}
+
+// PlannedResourceChange describes a resource that will be created, updated, replaced, or deleted. PreviousUid is only
+// set for replacements.
+type PlannedResourceChange struct {
+	Action      PlanAction  `json:"action" yaml:"action"`
+	Uid         ResourceUid `json:"uid" yaml:"uid"`
+	PreviousUid ResourceUid `json:"previousUid,omitempty" yaml:"previousUid,omitempty"`
+	Reasons     []string    `json:"reasons" yaml:"reasons"`
+}
+
+// Plan is the set of changes needed to move a state to a new set of workloads. It is created with State.Plan and
+// applied with State.Apply. A plan can only be applied to a state with the same workloads and resources as the one it
+// was created from. A plan may be serialized for review, in which case the workloads it was created from must be
+// passed to State.Apply again.
+type Plan[StateExtras any, WorkloadExtras any, ResourceExtras any] struct {
+	// BaseDigest identifies the workloads and resources of the state the plan was created from.
+	BaseDigest string `json:"baseDigest" yaml:"baseDigest"`
+	// Workloads lists the workload changes sorted by name.
+	Workloads []PlannedWorkloadChange `json:"workloads" yaml:"workloads"`
+	// Resources lists the resource changes. Creations, updates, and replacements are in dependency order followed by
+	// deletions sorted by uid.
+	Resources []PlannedResourceChange `json:"resources" yaml:"resources"`
+
+	desired *State[StateExtras, WorkloadExtras, ResourceExtras]
+}
+
+// IsEmpty returns true if the plan has no changes.
+func (p *Plan[StateExtras, WorkloadExtras, ResourceExtras]) IsEmpty() bool {
+	return len(p.Workloads) == 0 && len(p.Resources) == 0
+}
+
+// String returns a human readable preview of the plan with one line per change.
+func (p *Plan[StateExtras, WorkloadExtras, ResourceExtras]) String() string {
+	if p.IsEmpty() {
+		return "no changes\n"
+	}
+	symbols := map[PlanAction]string{PlanCreate: "+", PlanUpdate: "~", PlanReplace: "-/+", PlanDelete: "-"}
+	sb := new(strings.Builder)
+	for _, c := range p.Workloads {
+		_, _ = fmt.Fprintf(sb, "%s %s workload %s: %s\n", symbols[c.Action], c.Action, c.Name, strings.Join(c.Reasons, ", "))
+	}
+	for _, c := range p.Resources {
+		_, _ = fmt.Fprintf(sb, "%s %s resource %s: %s\n", symbols[c.Action], c.Action, c.Uid, strings.Join(c.Reasons, ", "))
+	}
+	return sb.String()
+}
+
+// Plan computes the changes needed to move this state to the given set of workloads. Workloads in the state that are
+// not in the given set will be deleted along with any resources that are no longer referenced. A resource whose type
+// or class changes while keeping the same id is replaced. This does not modify the state.
+func (s *State[StateExtras, WorkloadExtras, ResourceExtras]) Plan(workloads ...PlanWorkload[WorkloadExtras]) (*Plan[StateExtras, WorkloadExtras, ResourceExtras], error) {
+	baseDigest, err := s.planDigest()
+	if err != nil {
+		return nil, err
+	}
+
+	desired := *s
+	desired.Workloads = nil
+	desiredPtr := &desired
+	for i, w := range workloads {
+		if w.Spec == nil {
+			return nil, fmt.Errorf("workloads[%d]: spec is missing", i)
+		}
+		if desiredPtr, err = desiredPtr.WithWorkload(w.Spec, w.File, w.Extras); err != nil {
+			return nil, fmt.Errorf("workloads[%d]: %w", i, err)
+		}
+	}
+	if desiredPtr.Workloads == nil {
+		desiredPtr.Workloads = make(map[string]ScoreWorkloadState[WorkloadExtras])
+	}
+	if desiredPtr, err = desiredPtr.WithPrimedResources(); err != nil {
+		return nil, err
+	}
+
+	// remove any resources that are no longer referenced by a workload
+	referenced := make(map[ResourceUid]bool)
+	for workloadName, workload := range desiredPtr.Workloads {
+		for resName, res := range workload.Spec.Resources {
+			referenced[NewResourceUid(workloadName, resName, res.Type, res.Class, res.Id)] = true
+		}
+	}
+	for uid := range desiredPtr.Resources {
+		if !referenced[uid] {
+			delete(desiredPtr.Resources, uid)
+		}
+	}
+
+	out := &Plan[StateExtras, WorkloadExtras, ResourceExtras]{
+		BaseDigest: baseDigest,
+		Workloads:  make([]PlannedWorkloadChange, 0),
+		Resources:  make([]PlannedResourceChange, 0),
+		desired:    desiredPtr,
+	}
+
+	workloadNames := slices.Collect(maps.Keys(s.Workloads))
+	for name := range desiredPtr.Workloads {
+		if _, ok := s.Workloads[name]; !ok {
+			workloadNames = append(workloadNames, name)
+		}
+	}
+	sort.Strings(workloadNames)
+	for _, name := range workloadNames {
+		current, inCurrent := s.Workloads[name]
+		next, inDesired := desiredPtr.Workloads[name]
+		if !inCurrent {
+			out.Workloads = append(out.Workloads, PlannedWorkloadChange{Action: PlanCreate, Name: name, Reasons: []string{"new workload"}})
+		} else if !inDesired {
+			out.Workloads = append(out.Workloads, PlannedWorkloadChange{Action: PlanDelete, Name: name, Reasons: []string{"workload removed"}})
+		} else if !current.Spec.Equal(&next.Spec) {
+			out.Workloads = append(out.Workloads, PlannedWorkloadChange{Action: PlanUpdate, Name: name, Reasons: []string{"spec changed"}})
+		}
+	}
+
+	// pair up resources that were removed and added with the same id, these are replacements
+	removedById := make(map[string][]ResourceUid)
+	for uid := range s.Resources {
+		if _, ok := desiredPtr.Resources[uid]; !ok {
+			removedById[uid.Id()] = append(removedById[uid.Id()], uid)
+		}
+	}
+	addedById := make(map[string][]ResourceUid)
+	for uid := range desiredPtr.Resources {
+		if _, ok := s.Resources[uid]; !ok {
+			addedById[uid.Id()] = append(addedById[uid.Id()], uid)
+		}
+	}
+	replaced := make(map[ResourceUid]ResourceUid)
+	replacedPrevious := make(map[ResourceUid]bool)
+	for id, added := range addedById {
+		if removed := removedById[id]; len(added) == 1 && len(removed) == 1 {
+			replaced[added[0]] = removed[0]
+			replacedPrevious[removed[0]] = true
+		}
+	}
+
+	sortedUids, err := desiredPtr.GetSortedResourceUids()
+	if err != nil {
+		return nil, err
+	}
+	for _, uid := range sortedUids {
+		next := desiredPtr.Resources[uid]
+		if previousUid, ok := replaced[uid]; ok {
+			reasons := make([]string, 0, 2)
+			if previousUid.Type() != uid.Type() {
+				reasons = append(reasons, fmt.Sprintf("type changed from '%s' to '%s'", previousUid.Type(), uid.Type()))
+			}
+			if previousUid.Class() != uid.Class() {
+				reasons = append(reasons, fmt.Sprintf("class changed from '%s' to '%s'", previousUid.Class(), uid.Class()))
+			}
+			out.Resources = append(out.Resources, PlannedResourceChange{Action: PlanReplace, Uid: uid, PreviousUid: previousUid, Reasons: reasons})
+		} else if current, ok := s.Resources[uid]; !ok {
+			out.Resources = append(out.Resources, PlannedResourceChange{
+				Action: PlanCreate, Uid: uid, Reasons: []string{fmt.Sprintf("new resource from workload '%s'", next.SourceWorkload)},
+			})
+		} else {
+			reasons := append(mapKeyChanges("params", current.Params, next.Params), mapKeyChanges("metadata", current.Metadata, next.Metadata)...)
+			if len(reasons) > 0 {
+				out.Resources = append(out.Resources, PlannedResourceChange{Action: PlanUpdate, Uid: uid, Reasons: reasons})
+			}
+		}
+	}
+
+	deleted := make([]ResourceUid, 0)
+	for uid := range s.Resources {
+		if _, ok := desiredPtr.Resources[uid]; !ok && !replacedPrevious[uid] {
+			deleted = append(deleted, uid)
+		}
+	}
+	slices.Sort(deleted)
+	for _, uid := range deleted {
+		out.Resources = append(out.Resources, PlannedResourceChange{
+			Action: PlanDelete, Uid: uid, Reasons: []string{"no longer referenced by any workload"},
+		})
+	}
+	return out, nil
+}
+
+// Apply returns a new copy of State with the changes in the plan applied. The plan must have been created from a state
+// with the same workloads and resources, otherwise an error is returned and the plan should be recomputed. The
+// internal state and outputs of updated resources are retained.
+// If workloads are given, the plan is recomputed from them and an error is returned unless it has the same changes as
+// the given plan. This allows a plan that was serialized and approved to be applied. Without workloads, the plan must
+// have been returned by State.Plan in this process.
+// This is not a deep copy, but any writes are executed in a copy-on-write manner to avoid modifying the source.
+func (s *State[StateExtras, WorkloadExtras, ResourceExtras]) Apply(plan *Plan[StateExtras, WorkloadExtras, ResourceExtras], workloads ...PlanWorkload[WorkloadExtras]) (*State[StateExtras, WorkloadExtras, ResourceExtras], error) {
+	if plan == nil || (plan.desired == nil && len(workloads) == 0) {
+		return nil, fmt.Errorf("plan is not initialized")
+	}
+	digest, err := s.planDigest()
+	if err != nil {
+		return nil, err
+	}
+	if digest != plan.BaseDigest {
+		return nil, fmt.Errorf("state has changed since the plan was created")
+	}
+	if len(workloads) > 0 {
+		recomputed, err := s.Plan(workloads...)
+		if err != nil {
+			return nil, err
+		} else if !slices.EqualFunc(plan.Workloads, recomputed.Workloads, plannedWorkloadChangeEqual) ||
+			!slices.EqualFunc(plan.Resources, recomputed.Resources, plannedResourceChangeEqual) {
+			return nil, fmt.Errorf("workloads do not match the changes in the plan")
+		}
+		plan = recomputed
+	}
+
+	out := *s
+	out.Workloads = maps.Clone(plan.desired.Workloads)
+	if s.Resources == nil {
+		out.Resources = make(map[ResourceUid]ScoreResourceState[ResourceExtras])
+	} else {
+		out.Resources = maps.Clone(s.Resources)
+	}
+	for _, c := range plan.Resources {
+		switch c.Action {
+		case PlanCreate:
+			out.Resources[c.Uid] = plan.desired.Resources[c.Uid]
+		case PlanReplace:
+			delete(out.Resources, c.PreviousUid)
+			out.Resources[c.Uid] = plan.desired.Resources[c.Uid]
+		case PlanUpdate:
+			existing := out.Resources[c.Uid]
+			next := plan.desired.Resources[c.Uid]
+			existing.Params = next.Params
+			existing.Metadata = next.Metadata
+			existing.SourceWorkload = next.SourceWorkload
+			out.Resources[c.Uid] = existing
+		case PlanDelete:
+			delete(out.Resources, c.Uid)
+		}
+	}
+	return &out, nil
+}
+
+// planDigest returns a digest of the workload specs and resource identities of the state.
+func (s *State[StateExtras, WorkloadExtras, ResourceExtras]) planDigest() (string, error) {
+	type resourceDigest struct {
+		Params         map[string]interface{} `json:"params"`
+		Metadata       map[string]interface{} `json:"metadata"`
+		SourceWorkload string                 `json:"sourceWorkload"`
+	}
+	workloads := make(map[string]score.Workload, len(s.Workloads))
+	for name, w := range s.Workloads {
+		workloads[name] = w.Spec
+	}
+	resources := make(map[ResourceUid]resourceDigest, len(s.Resources))
+	for uid, r := range s.Resources {
+		resources[uid] = resourceDigest{Params: r.Params, Metadata: r.Metadata, SourceWorkload: r.SourceWorkload}
+	}
+	raw, err := json.Marshal(map[string]interface{}{"workloads": workloads, "resources": resources})
+	if err != nil {
+		return "", fmt.Errorf("failed to compute state digest: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func plannedWorkloadChangeEqual(a, b PlannedWorkloadChange) bool {
+	return a.Action == b.Action && a.Name == b.Name && slices.Equal(a.Reasons, b.Reasons)
+}
+
+func plannedResourceChangeEqual(a, b PlannedResourceChange) bool {
+	return a.Action == b.Action && a.Uid == b.Uid && a.PreviousUid == b.PreviousUid && slices.Equal(a.Reasons, b.Reasons)
+}
+
+// mapKeyChanges returns a description of each top level key that differs between the two maps.
+func mapKeyChanges(prefix string, current, next map[string]interface{}) []string {
+	keys := slices.Collect(maps.Keys(current))
+	for k := range next {
+		if _, ok := current[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	out := make([]string, 0)
+	for _, k := range keys {
+		cv, inCurrent := current[k]
+		nv, inNext := next[k]
+		if !inCurrent {
+			out = append(out, fmt.Sprintf("%s.%s added", prefix, k))
+		} else if !inNext {
+			out = append(out, fmt.Sprintf("%s.%s removed", prefix, k))
+		} else if !score.SemanticEqual(cv, nv) {
+			out = append(out, fmt.Sprintf("%s.%s changed", prefix, k))
+		}
+	}
+	return out
+}
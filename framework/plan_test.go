@@ -0,0 +1,286 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framework
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func planWorkloads(t *testing.T, specs ...string) []PlanWorkload[NoExtras] {
+	t.Helper()
+	out := make([]PlanWorkload[NoExtras], len(specs))
+	for i, spec := range specs {
+		out[i] = PlanWorkload[NoExtras]{Spec: mustLoadWorkload(t, spec)}
+	}
+	return out
+}
+
+func TestPlan_fromEmpty(t *testing.T) {
+	start := new(State[NoExtras, NoExtras, NoExtras])
+	plan, err := start.Plan(planWorkloads(t, `
+metadata:
+  name: example
+containers:
+  main:
+    image: nginx
+resources:
+  db:
+    type: postgres
+  dns:
+    type: dns
+    id: shared-dns
+  route:
+    type: route
+    params:
+      host: ${resources.dns.host}
+`)...)
+	require.NoError(t, err)
+	assert.Equal(t, []PlannedWorkloadChange{{Action: PlanCreate, Name: "example", Reasons: []string{"new workload"}}}, plan.Workloads)
+	assert.Equal(t, []PlannedResourceChange{
+		{Action: PlanCreate, Uid: "dns.default#shared-dns", Reasons: []string{"new resource from workload 'example'"}},
+		{Action: PlanCreate, Uid: "postgres.default#example.db", Reasons: []string{"new resource from workload 'example'"}},
+		{Action: PlanCreate, Uid: "route.default#example.route", Reasons: []string{"new resource from workload 'example'"}},
+	}, plan.Resources)
+	assert.Equal(t, `+ create workload example: new workload
++ create resource dns.default#shared-dns: new resource from workload 'example'
++ create resource postgres.default#example.db: new resource from workload 'example'
++ create resource route.default#example.route: new resource from workload 'example'
+`, plan.String())
+
+	assert.Len(t, start.Workloads, 0, "planning must not modify the state")
+	assert.Len(t, start.Resources, 0, "planning must not modify the state")
+
+	next, err := start.Apply(plan)
+	require.NoError(t, err)
+	assert.Len(t, next.Workloads, 1)
+	assert.Len(t, next.Resources, 3)
+	assert.Len(t, start.Workloads, 0, "applying must not modify the state")
+
+	again, err := next.Plan(planWorkloads(t, `
+metadata:
+  name: example
+containers:
+  main:
+    image: nginx
+resources:
+  db:
+    type: postgres
+  dns:
+    type: dns
+    id: shared-dns
+  route:
+    type: route
+    params:
+      host: ${resources.dns.host}
+`)...)
+	require.NoError(t, err)
+	assert.True(t, again.IsEmpty())
+	assert.Equal(t, "no changes\n", again.String())
+}
+
+func TestPlan_changes(t *testing.T) {
+	start := new(State[NoExtras, NoExtras, NoExtras])
+	plan, err := start.Plan(planWorkloads(t, `
+metadata:
+  name: one
+containers:
+  main:
+    image: nginx
+resources:
+  db:
+    type: postgres
+    params:
+      size: small
+  cache:
+    type: redis
+  bucket:
+    type: s3
+`, `
+metadata:
+  name: two
+containers:
+  main:
+    image: nginx
+`)...)
+	require.NoError(t, err)
+	current, err := start.Apply(plan)
+	require.NoError(t, err)
+	res := current.Resources["postgres.default#one.db"]
+	res.Outputs = map[string]interface{}{"host": "localhost"}
+	current.Resources["postgres.default#one.db"] = res
+
+	plan, err = current.Plan(planWorkloads(t, `
+metadata:
+  name: one
+containers:
+  main:
+    image: nginx:latest
+resources:
+  db:
+    type: postgres
+    params:
+      size: large
+  cache:
+    type: redis
+    class: ha
+  queue:
+    type: amqp
+`)...)
+	require.NoError(t, err)
+	assert.Equal(t, []PlannedWorkloadChange{
+		{Action: PlanUpdate, Name: "one", Reasons: []string{"spec changed"}},
+		{Action: PlanDelete, Name: "two", Reasons: []string{"workload removed"}},
+	}, plan.Workloads)
+	assert.Equal(t, []PlannedResourceChange{
+		{Action: PlanCreate, Uid: "amqp.default#one.queue", Reasons: []string{"new resource from workload 'one'"}},
+		{Action: PlanUpdate, Uid: "postgres.default#one.db", Reasons: []string{"params.size changed"}},
+		{Action: PlanReplace, Uid: "redis.ha#one.cache", PreviousUid: "redis.default#one.cache", Reasons: []string{"class changed from 'default' to 'ha'"}},
+		{Action: PlanDelete, Uid: "s3.default#one.bucket", Reasons: []string{"no longer referenced by any workload"}},
+	}, plan.Resources)
+	assert.Contains(t, plan.String(), "-/+ replace resource redis.ha#one.cache: class changed from 'default' to 'ha'\n")
+
+	next, err := current.Apply(plan)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one"}, sortedStringMapKeys(next.Workloads))
+	assert.Equal(t, "nginx:latest", next.Workloads["one"].Spec.Containers["main"].Image)
+	assert.Len(t, next.Resources, 3)
+	assert.Equal(t, map[string]interface{}{"size": "large"}, next.Resources["postgres.default#one.db"].Params)
+	assert.Equal(t, map[string]interface{}{"host": "localhost"}, next.Resources["postgres.default#one.db"].Outputs, "outputs of updated resources are retained")
+	assert.Equal(t, res.Guid, next.Resources["postgres.default#one.db"].Guid)
+	assert.Contains(t, next.Resources, ResourceUid("redis.ha#one.cache"))
+	assert.NotContains(t, next.Resources, ResourceUid("redis.default#one.cache"))
+	assert.Len(t, current.Resources, 3, "applying must not modify the state")
+	assert.Contains(t, current.Resources, ResourceUid("s3.default#one.bucket"))
+}
+
+func TestPlan_typeChange(t *testing.T) {
+	start := new(State[NoExtras, NoExtras, NoExtras])
+	plan, err := start.Plan(planWorkloads(t, `
+metadata:
+  name: example
+resources:
+  db:
+    type: mysql
+`)...)
+	require.NoError(t, err)
+	current, err := start.Apply(plan)
+	require.NoError(t, err)
+
+	plan, err = current.Plan(planWorkloads(t, `
+metadata:
+  name: example
+resources:
+  db:
+    type: postgres
+    class: large
+`)...)
+	require.NoError(t, err)
+	assert.Equal(t, []PlannedResourceChange{{
+		Action: PlanReplace, Uid: "postgres.large#example.db", PreviousUid: "mysql.default#example.db",
+		Reasons: []string{"type changed from 'mysql' to 'postgres'", "class changed from 'default' to 'large'"},
+	}}, plan.Resources)
+}
+
+func TestApply_staleState(t *testing.T) {
+	start := new(State[NoExtras, NoExtras, NoExtras])
+	plan, err := start.Plan(planWorkloads(t, `
+metadata:
+  name: example
+`)...)
+	require.NoError(t, err)
+
+	changed := mustAddWorkload(t, start, `
+metadata:
+  name: other
+`)
+	_, err = changed.Apply(plan)
+	assert.EqualError(t, err, "state has changed since the plan was created")
+
+	_, err = start.Apply(&Plan[NoExtras, NoExtras, NoExtras]{})
+	assert.EqualError(t, err, "plan is not initialized")
+	_, err = start.Apply(nil, planWorkloads(t, `
+metadata:
+  name: example
+`)...)
+	assert.EqualError(t, err, "plan is not initialized")
+
+	_, err = start.Plan(PlanWorkload[NoExtras]{})
+	assert.EqualError(t, err, "workloads[0]: spec is missing")
+}
+
+func TestApply_serializedPlan(t *testing.T) {
+	start := new(State[NoExtras, NoExtras, NoExtras])
+	workloads := planWorkloads(t, `
+metadata:
+  name: example
+resources:
+  db:
+    type: postgres
+`)
+	plan, err := start.Plan(workloads...)
+	require.NoError(t, err)
+	raw, err := json.Marshal(plan)
+	require.NoError(t, err)
+
+	var approved Plan[NoExtras, NoExtras, NoExtras]
+	require.NoError(t, json.Unmarshal(raw, &approved))
+	_, err = start.Apply(&approved)
+	assert.EqualError(t, err, "plan is not initialized")
+
+	next, err := start.Apply(&approved, workloads...)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example"}, sortedStringMapKeys(next.Workloads))
+	assert.Contains(t, next.Resources, ResourceUid("postgres.default#example.db"))
+
+	_, err = start.Apply(&approved, planWorkloads(t, `
+metadata:
+  name: example
+resources:
+  db:
+    type: mysql
+`)...)
+	assert.EqualError(t, err, "workloads do not match the changes in the plan")
+
+	_, err = next.Apply(&approved, workloads...)
+	assert.EqualError(t, err, "state has changed since the plan was created")
+}
+
+func TestPlan_numericParamsAreEqual(t *testing.T) {
+	start := new(State[NoExtras, NoExtras, NoExtras])
+	workloads := planWorkloads(t, `
+metadata:
+  name: example
+resources:
+  db:
+    type: postgres
+    params:
+      size: 1
+`)
+	plan, err := start.Plan(workloads...)
+	require.NoError(t, err)
+	current, err := start.Apply(plan)
+	require.NoError(t, err)
+	res := current.Resources["postgres.default#example.db"]
+	res.Params = map[string]interface{}{"size": 1.0}
+	current.Resources["postgres.default#example.db"] = res
+
+	plan, err = current.Plan(workloads...)
+	require.NoError(t, err)
+	assert.True(t, plan.IsEmpty(), plan.String())
+}
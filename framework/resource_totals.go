@@ -0,0 +1,37 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framework
+
+import (
+	"fmt"
+
+	score "github.com/score-spec/score-go/types"
+)
+
+// ResourceTotals returns the container cpu and memory requests and limits summed per workload, along with the sum
+// across all workloads in the state. This does not modify the state.
+func (s *State[StateExtras, WorkloadExtras, ResourceExtras]) ResourceTotals() (total score.ResourceTotals, perWorkload map[string]score.ResourceTotals, err error) {
+	perWorkload = make(map[string]score.ResourceTotals, len(s.Workloads))
+	for _, workloadName := range sortedStringMapKeys(s.Workloads) {
+		spec := s.Workloads[workloadName].Spec
+		t, _, err := score.WorkloadResourceTotals(&spec)
+		if err != nil {
+			return score.ResourceTotals{}, nil, fmt.Errorf("workload '%s': %w", workloadName, err)
+		}
+		perWorkload[workloadName] = t
+		total = total.Add(t)
+	}
+	return total, perWorkload, nil
+}
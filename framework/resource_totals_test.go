@@ -0,0 +1,74 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceTotals(t *testing.T) {
+	start := new(State[NoExtras, NoExtras, NoExtras])
+	total, perWorkload, err := start.ResourceTotals()
+	require.NoError(t, err)
+	assert.True(t, total.RequestsCpu.IsZero())
+	assert.Empty(t, perWorkload)
+
+	start = mustAddWorkload(t, start, `
+metadata: {name: example1}
+containers:
+  a:
+    image: a
+    resources:
+      requests: {cpu: 250m, memory: 1Gi}
+      limits: {cpu: "1", memory: 2Gi}
+  b:
+    image: b
+    resources:
+      requests: {cpu: 250m, memory: 1Gi}
+`)
+	start = mustAddWorkload(t, start, `
+metadata: {name: example2}
+containers:
+  c:
+    image: c
+    resources:
+      requests: {cpu: "1.5", memory: 1.5Gi}
+`)
+	total, perWorkload, err = start.ResourceTotals()
+	require.NoError(t, err)
+	assert.Equal(t, "500m", perWorkload["example1"].RequestsCpu.String())
+	assert.Equal(t, "2Gi", perWorkload["example1"].RequestsMemory.String())
+	assert.Equal(t, "1500m", perWorkload["example2"].RequestsCpu.String())
+	assert.Equal(t, "2", total.RequestsCpu.String())
+	assert.Equal(t, "3584Mi", total.RequestsMemory.String())
+	assert.Equal(t, "1", total.LimitsCpu.String())
+	assert.Equal(t, "2Gi", total.LimitsMemory.String())
+	assert.True(t, total.LimitsCpuUnbounded, "containers b and c have no limits")
+	assert.True(t, total.LimitsMemoryUnbounded)
+
+	start = mustAddWorkload(t, start, `
+metadata: {name: example3}
+containers:
+  d:
+    image: d
+    resources:
+      limits: {memory: 10 Gi}
+`)
+	_, _, err = start.ResourceTotals()
+	assert.EqualError(t, err, "workload 'example3': containers: d: resources: limits: memory: quantity '10 Gi' has an unknown unit ' Gi'")
+}
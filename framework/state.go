@@ -89,7 +89,8 @@ type OutputLookupFunc func(keys ...string) (interface{}, error)
 
 // WithWorkload returns a new copy of State with the workload added, if the workload already exists with the same name
 // then it will be replaced.
-// This is not a deep copy, but any writes are executed in a copy-on-write manner to avoid modifying the source.
+// This is not a deep copy, but any writes are executed in a copy-on-write manner to avoid modifying the source. The
+// workload spec itself is deep copied so that later changes to the given spec do not affect the state.
 func (s *State[StateExtras, WorkloadExtras, ResourceExtras]) WithWorkload(spec *score.Workload, filePath *string, extras WorkloadExtras) (*State[StateExtras, WorkloadExtras, ResourceExtras], error) {
 	out := *s
 	if s.Workloads == nil {
@@ -103,7 +104,7 @@ func (s *State[StateExtras, WorkloadExtras, ResourceExtras]) WithWorkload(spec *
 		return nil, fmt.Errorf("metadata: name: is missing or is not a string")
 	}
 	out.Workloads[name] = ScoreWorkloadState[WorkloadExtras]{
-		Spec:   *spec,
+		Spec:   *spec.DeepCopy(),
 		File:   filePath,
 		Extras: extras,
 	}
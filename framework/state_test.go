@@ -105,6 +105,22 @@ containers:
 		assert.Len(t, next1.Workloads, 1)
 		assert.Len(t, next2.Workloads, 2)
 	})
+
+	t.Run("spec is copied", func(t *testing.T) {
+		spec := mustLoadWorkload(t, `
+metadata:
+  name: example
+containers:
+  hello-world:
+    image: hi
+`)
+		next, err := start.WithWorkload(spec, nil, NoExtras{})
+		require.NoError(t, err)
+		spec.Metadata["extra"] = "value"
+		spec.Containers["hello-world"] = score.Container{Image: "other"}
+		assert.NotContains(t, next.Workloads["example"].Spec.Metadata, "extra")
+		assert.Equal(t, "hi", next.Workloads["example"].Spec.Containers["hello-world"].Image)
+	})
 }
 
 func TestWithPrimedResources(t *testing.T) {
@@ -31,7 +31,7 @@ require (
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/olekukonko/tablewriter v1.1.4
-	github.com/opencontainers/go-digest v1.0.0 // indirect
+	github.com/opencontainers/go-digest v1.0.0
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/sync v0.22.0 // indirect
 )
@@ -0,0 +1,155 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/score-spec/score-go/schema"
+	"github.com/score-spec/score-go/types"
+)
+
+// FormatWorkloadYaml rewrites the source of a Score file into its canonical form. The common upgrade transforms from
+// schema.ApplyCommonUpgradeTransforms are applied permanently, keys are sorted canonically, and container cpu and memory
+// quantities are normalized. Comments in the source are retained. The returned messages describe each change that was
+// made other than formatting. The source must be a valid Score file once the upgrade transforms are applied.
+func FormatWorkloadYaml(source []byte) ([]byte, []string, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(source, &raw); err != nil {
+		return nil, nil, fmt.Errorf("decoding source YAML structure: %w", err)
+	}
+	changes, err := schema.ApplyCommonUpgradeTransforms(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("upgrading score file: %w", err)
+	}
+	if err := schema.Validate(raw); err != nil {
+		return nil, nil, fmt.Errorf("validating score file: %w", err)
+	}
+	var workload types.Workload
+	if err := MapSpec(&workload, raw); err != nil {
+		return nil, nil, fmt.Errorf("mapping score file: %w", err)
+	}
+	changes = append(changes, normalizeQuantities(&workload)...)
+
+	node, err := preservingWorkloadNode(&workload, source)
+	if err != nil {
+		return nil, nil, err
+	}
+	sortCommentedDocument(node)
+	out, err := encodeNode(node)
+	if err != nil {
+		return nil, nil, err
+	}
+	var formatted map[string]interface{}
+	if err := yaml.Unmarshal(out, &formatted); err != nil {
+		return nil, nil, fmt.Errorf("decoding formatted YAML structure: %w", err)
+	} else if err := schema.Validate(formatted); err != nil {
+		return nil, nil, fmt.Errorf("validating formatted score file: %w", err)
+	}
+	return out, changes, nil
+}
+
+// sortCommentedDocument sorts the keys of the document canonically. A comment at the top of the document is attached to
+// the first key by the yaml decoder, so it is moved to whichever key is first after sorting.
+func sortCommentedDocument(node *yaml.Node) {
+	root := node
+	if root.Kind == yaml.DocumentNode && len(root.Content) == 1 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode || len(root.Content) == 0 {
+		sortNode(node, []string{})
+		return
+	}
+	topComment := root.Content[0].HeadComment
+	root.Content[0].HeadComment = ""
+	sortNode(node, []string{})
+	if topComment != "" {
+		if root.Content[0].HeadComment != "" {
+			topComment += "\n" + root.Content[0].HeadComment
+		}
+		root.Content[0].HeadComment = topComment
+	}
+}
+
+// normalizeQuantities rewrites the container cpu and memory requests and limits in their canonical form. Values that
+// cannot be parsed, or that have no canonical form which the schema accepts, are left unchanged.
+func normalizeQuantities(workload *types.Workload) []string {
+	changes := make([]string, 0)
+	normalize := func(path string, value *string, canonical func(types.Quantity) (string, bool)) {
+		if value == nil {
+			return
+		}
+		q, err := types.ParseQuantity(*value)
+		if err != nil {
+			return
+		}
+		if c, ok := canonical(q); ok && c != *value {
+			changes = append(changes, fmt.Sprintf("%s: normalized '%s' to '%s'", path, *value, c))
+			*value = c
+		}
+	}
+	for _, name := range sortedStringKeys(workload.Containers) {
+		c := workload.Containers[name]
+		if c.Resources == nil {
+			continue
+		}
+		for _, section := range []struct {
+			name   string
+			limits *types.ResourcesLimits
+		}{{"requests", c.Resources.Requests}, {"limits", c.Resources.Limits}} {
+			if section.limits != nil {
+				normalize(fmt.Sprintf("containers.%s.resources.%s.cpu", name, section.name), section.limits.Cpu, canonicalCpu)
+				normalize(fmt.Sprintf("containers.%s.resources.%s.memory", name, section.name), section.limits.Memory, canonicalMemory)
+			}
+		}
+	}
+	return changes
+}
+
+// canonicalCpu returns the cpu quantity as whole cpus or milli-cpus, which are the forms accepted by the schema. For
+// example 0.5 is 500m and 2000m is 2. There is no canonical form for negative values or values finer than 1m.
+func canonicalCpu(q types.Quantity) (string, bool) {
+	milli, ok := q.AsMilliInt64()
+	if !ok || milli < 0 || q.Cmp(types.MustParseQuantity(fmt.Sprintf("%dm", milli))) != 0 {
+		return "", false
+	} else if milli%1000 == 0 {
+		return strconv.FormatInt(milli/1000, 10), true
+	}
+	return fmt.Sprintf("%dm", milli), true
+}
+
+// canonicalMemory returns the memory quantity in bytes with the largest suffix that represents it as an integer,
+// using binary suffixes for binary quantities and the uppercase decimal suffixes accepted by the schema otherwise. For
+// example 1024Mi is 1Gi and 1500000 is 1500K. There is no canonical form for values that are not a positive whole
+// number of bytes.
+func canonicalMemory(q types.Quantity) (string, bool) {
+	value, ok := q.AsInt64()
+	if !ok || value <= 0 || q.Cmp(types.MustParseQuantity(strconv.FormatInt(value, 10))) != 0 {
+		return "", false
+	}
+	base, suffixes := int64(1000), []string{"", "K", "M", "G", "T", "P", "E"}
+	if q.Format() == types.BinarySI {
+		base, suffixes = 1024, []string{"", "Ki", "Mi", "Gi", "Ti", "Pi", "Ei"}
+	}
+	power := 0
+	for power < len(suffixes)-1 && value%base == 0 {
+		value /= base
+		power++
+	}
+	return strconv.FormatInt(value, 10) + suffixes[power], true
+}
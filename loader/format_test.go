@@ -0,0 +1,136 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatWorkloadYaml(t *testing.T) {
+	source := `# my service
+containers:
+  main:
+    volumes:
+      - source: ${resources.data}
+        target: /data
+        read_only: true
+    files:
+      - target: /etc/config
+        content: ["line1", "line2"]
+    resources:
+      limits:
+        memory: 1024Mi # the most we need
+        cpu: "0.5"
+    image: nginx # always latest
+metadata:
+  name: example
+apiVersion: score.dev/v1b1
+resources:
+  data:
+    type: volume
+`
+	out, changes, err := FormatWorkloadYaml([]byte(source))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"containers.main.files.0.content: converted from array",
+		"containers.main.files: migrated to object",
+		"containers.main.volumes.0.read_only: migrated to readOnly",
+		"containers.main.volumes: migrated to object",
+		"containers.main.resources.limits.cpu: normalized '0.5' to '500m'",
+		"containers.main.resources.limits.memory: normalized '1024Mi' to '1Gi'",
+	}, changes)
+	assert.Equal(t, `# my service
+apiVersion: score.dev/v1b1
+metadata:
+  name: example
+containers:
+  main:
+    image: nginx # always latest
+    files:
+      /etc/config:
+        content: |-
+          line1
+          line2
+    volumes:
+      /data:
+        source: ${resources.data}
+        readOnly: true
+    resources:
+      limits:
+        cpu: "500m"
+        memory: 1Gi # the most we need
+resources:
+  data:
+    type: volume
+`, string(out))
+
+	again, changes, err := FormatWorkloadYaml(out)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, string(out), string(again), "formatting must be idempotent")
+}
+
+func TestFormatWorkloadYaml_quantities(t *testing.T) {
+	for _, tc := range []struct {
+		field     string
+		value     string
+		formatted string
+	}{
+		{field: "memory", value: "1500K", formatted: "1500K"},
+		{field: "memory", value: "1500000", formatted: "1500K"},
+		{field: "memory", value: "0.1Ki", formatted: "0.1Ki"},
+		{field: "memory", value: "0.5Ki", formatted: "512"},
+		{field: "memory", value: "2048Mi", formatted: "2Gi"},
+		{field: "memory", value: "1.5G", formatted: "1500M"},
+		{field: "memory", value: "1000E", formatted: "1000E"},
+		{field: "memory", value: "0.5", formatted: "0.5"},
+		{field: "cpu", value: "1000", formatted: "1000"},
+		{field: "cpu", value: "2000m", formatted: "2"},
+		{field: "cpu", value: "0.25", formatted: "250m"},
+		{field: "cpu", value: "0.0001", formatted: "0.0001"},
+	} {
+		t.Run(tc.field+"="+tc.value, func(t *testing.T) {
+			source := fmt.Sprintf("apiVersion: score.dev/v1b1\nmetadata:\n  name: example\ncontainers:\n  main:\n    image: nginx\n    resources:\n      limits:\n        %s: \"%s\"\n", tc.field, tc.value)
+			out, changes, err := FormatWorkloadYaml([]byte(source))
+			require.NoError(t, err)
+			assert.Contains(t, string(out), fmt.Sprintf("%s: \"%s\"", tc.field, tc.formatted))
+			if tc.formatted == tc.value {
+				assert.Empty(t, changes)
+			} else {
+				assert.Equal(t, []string{fmt.Sprintf("containers.main.resources.limits.%s: normalized '%s' to '%s'", tc.field, tc.value, tc.formatted)}, changes)
+			}
+
+			again, changes, err := FormatWorkloadYaml(out)
+			require.NoError(t, err)
+			assert.Empty(t, changes)
+			assert.Equal(t, string(out), string(again), "formatting must be idempotent")
+		})
+	}
+}
+
+func TestFormatWorkloadYaml_errors(t *testing.T) {
+	_, _, err := FormatWorkloadYaml([]byte("{"))
+	assert.ErrorContains(t, err, "decoding source YAML structure")
+
+	_, _, err = FormatWorkloadYaml([]byte("apiVersion: score.dev/v1b1\nmetadata:\n  name: example\ncontainers:\n  main:\n    image: nginx\n    files:\n      - content: x\n"))
+	assert.EqualError(t, err, "upgrading score file: containers.main.files.0.target: is missing or is not a string")
+
+	_, _, err = FormatWorkloadYaml([]byte("apiVersion: score.dev/v1b1\nmetadata:\n  name: example\n"))
+	assert.ErrorContains(t, err, "validating score file")
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/score-spec/score-go/types"
+)
+
+var (
+	secretVariableName = regexp.MustCompile(`(?i)(PASSWORD|PASSWD|SECRET|TOKEN|API_?KEY|PRIVATE_?KEY|ACCESS_?KEY|CREDENTIAL)`)
+	secretValuePattern = regexp.MustCompile(`(AKIA[0-9A-Z]{16}|-----BEGIN [A-Z ]*PRIVATE KEY-----|gh[pousr]_[A-Za-z0-9]{36}|xox[baprs]-[A-Za-z0-9-]{10,})`)
+	shellBinaries      = map[string]bool{
+		"sh": true, "bash": true, "zsh": true, "ash": true, "dash": true, "ksh": true, "csh": true, "tcsh": true,
+		"fish": true, "cmd": true, "cmd.exe": true, "powershell": true, "powershell.exe": true, "pwsh": true,
+	}
+	loopbackHosts = map[string]bool{"127.0.0.1": true, "localhost": true, "::1": true, "[::1]": true}
+)
+
+// SecurityLintRules returns the built-in set of security lint rules. These are not applied by Validate and should be
+// opted into by passing them to ValidateWithRules, for example behind a --lint flag:
+//
+//	err := loader.ValidateWithRules(workload, loader.SecurityLintRules()...)
+//
+// The following rules are included:
+//
+// - Container images must have an explicit tag or digest which is not "latest"
+//
+// - Container variables with secret-like names or values must use placeholders rather than literal values
+//
+// - Container files must not be world-writable
+//
+// - HTTPS probes must not target the loopback address, since this implies the certificate is not verified
+//
+// - Exec probes must not invoke a shell
+func SecurityLintRules() []ValidationRule {
+	return []ValidationRule{
+		ValidationRuleFunc(lintImageTags),
+		ValidationRuleFunc(lintSecretVariables),
+		ValidationRuleFunc(lintFileModes),
+		ValidationRuleFunc(lintProbes),
+	}
+}
+
+// sortedContainerNames returns the container names in a stable order so that lint messages are deterministic.
+func sortedContainerNames(workload *types.Workload) []string {
+	names := make([]string, 0, len(workload.Containers))
+	for name := range workload.Containers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lintImageTags reports images using the latest tag or no tag at all. Images referenced by digest are accepted.
+func lintImageTags(workload *types.Workload) []string {
+	errMsgs := []string{}
+	for _, name := range sortedContainerNames(workload) {
+		image := workload.Containers[name].Image
+		// "." indicates that the image is built locally by the implementation.
+		if image == "." || strings.Contains(image, "@") {
+			continue
+		}
+		tag := ""
+		if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+			tag = image[i+1:]
+		}
+		if tag == "" {
+			errMsgs = append(errMsgs, fmt.Sprintf("container %q image %q has no tag, pin a specific tag or digest", name, image))
+		} else if tag == "latest" {
+			errMsgs = append(errMsgs, fmt.Sprintf("container %q image %q uses the mutable latest tag, pin a specific tag or digest", name, image))
+		}
+	}
+	return errMsgs
+}
+
+// lintSecretVariables reports variables that appear to contain literal secrets rather than resource placeholders.
+func lintSecretVariables(workload *types.Workload) []string {
+	errMsgs := []string{}
+	for _, name := range sortedContainerNames(workload) {
+		variables := workload.Containers[name].Variables
+		keys := make([]string, 0, len(variables))
+		for k := range variables {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			value := variables[k]
+			if value == "" || len(allPlaceholdersInString(value)) > 0 {
+				continue
+			}
+			if secretVariableName.MatchString(k) || secretValuePattern.MatchString(value) {
+				errMsgs = append(errMsgs, fmt.Sprintf("container %q variable %q appears to contain a literal secret, use a resource placeholder instead", name, k))
+			}
+		}
+	}
+	return errMsgs
+}
+
+// lintFileModes reports files which are world-writable.
+func lintFileModes(workload *types.Workload) []string {
+	errMsgs := []string{}
+	for _, name := range sortedContainerNames(workload) {
+		files := workload.Containers[name].Files
+		targets := make([]string, 0, len(files))
+		for target := range files {
+			targets = append(targets, target)
+		}
+		sort.Strings(targets)
+		for _, target := range targets {
+			mode := files[target].Mode
+			if mode == nil {
+				continue
+			}
+			if m, err := strconv.ParseUint(*mode, 8, 32); err == nil && m&0o002 != 0 {
+				errMsgs = append(errMsgs, fmt.Sprintf("container %q file %q has world-writable mode %s", name, target, *mode))
+			}
+		}
+	}
+	return errMsgs
+}
+
+// lintProbes reports HTTPS probes against the loopback address and exec probes which invoke a shell.
+func lintProbes(workload *types.Workload) []string {
+	errMsgs := []string{}
+	for _, name := range sortedContainerNames(workload) {
+		c := workload.Containers[name]
+		for _, probe := range []struct {
+			kind  string
+			probe *types.ContainerProbe
+		}{{"livenessProbe", c.LivenessProbe}, {"readinessProbe", c.ReadinessProbe}} {
+			if probe.probe == nil {
+				continue
+			}
+			if hg := probe.probe.HttpGet; hg != nil && hg.Scheme != nil && *hg.Scheme == types.HttpProbeSchemeHTTPS && hg.Host != nil && loopbackHosts[strings.ToLower(*hg.Host)] {
+				errMsgs = append(errMsgs, fmt.Sprintf("container %q %s uses HTTPS against loopback host %q which skips certificate verification", name, probe.kind, *hg.Host))
+			}
+			if ex := probe.probe.Exec; ex != nil && len(ex.Command) > 0 && shellBinaries[strings.ToLower(path.Base(ex.Command[0]))] {
+				errMsgs = append(errMsgs, fmt.Sprintf("container %q %s exec command invokes the shell %q", name, probe.kind, ex.Command[0]))
+			}
+		}
+	}
+	return errMsgs
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/score-spec/score-go/types"
+)
+
+// ValidationRule is a non-schema validation rule that can be applied to a workload by Validate. Implementations
+// return one message per issue found, or nil if the workload satisfies the rule. Messages are collected into the
+// ValidationError returned by Validate.
+type ValidationRule interface {
+	Validate(workload *types.Workload) []string
+}
+
+// ValidationRuleFunc adapts an ordinary function to the ValidationRule interface.
+type ValidationRuleFunc func(workload *types.Workload) []string
+
+// Validate calls f(workload).
+func (f ValidationRuleFunc) Validate(workload *types.Workload) []string {
+	return f(workload)
+}
+
+type namedValidationRule struct {
+	name string
+	rule ValidationRule
+}
+
+var (
+	validationRulesLock sync.RWMutex
+	validationRules     []namedValidationRule
+)
+
+// RegisterValidationRule adds a named rule to the set of rules applied by Validate. Registered rules are applied
+// after the built-in rules in the order they were registered. An error is returned if a rule with the same name has
+// already been registered.
+func RegisterValidationRule(name string, rule ValidationRule) error {
+	if name == "" {
+		return fmt.Errorf("validation rule name must not be empty")
+	} else if rule == nil {
+		return fmt.Errorf("validation rule '%s' must not be nil", name)
+	}
+	validationRulesLock.Lock()
+	defer validationRulesLock.Unlock()
+	for _, r := range validationRules {
+		if r.name == name {
+			return fmt.Errorf("validation rule '%s' is already registered", name)
+		}
+	}
+	validationRules = append(validationRules, namedValidationRule{name: name, rule: rule})
+	return nil
+}
+
+// UnregisterValidationRule removes a rule previously added with RegisterValidationRule. It returns false if no rule
+// with the given name was registered.
+func UnregisterValidationRule(name string) bool {
+	validationRulesLock.Lock()
+	defer validationRulesLock.Unlock()
+	for i, r := range validationRules {
+		if r.name == name {
+			validationRules = append(validationRules[:i:i], validationRules[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// RegisteredValidationRules returns the names of the rules added with RegisterValidationRule in the order they are
+// applied.
+func RegisteredValidationRules() []string {
+	validationRulesLock.RLock()
+	defer validationRulesLock.RUnlock()
+	out := make([]string, len(validationRules))
+	for i, r := range validationRules {
+		out[i] = r.name
+	}
+	return out
+}
+
+// registeredValidationRules returns a snapshot of the registered rules so that they can be applied without holding
+// the lock.
+func registeredValidationRules() []ValidationRule {
+	validationRulesLock.RLock()
+	defer validationRulesLock.RUnlock()
+	out := make([]ValidationRule, len(validationRules))
+	for i, r := range validationRules {
+		out[i] = r.rule
+	}
+	return out
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/score-spec/score-go/types"
+)
+
+var imagesFromRegistryRule = ValidationRuleFunc(func(workload *types.Workload) []string {
+	var out []string
+	for _, name := range sortedKeys(workload.Containers) {
+		if image := workload.Containers[name].Image; !strings.HasPrefix(image, "registry.example.com/") {
+			out = append(out, fmt.Sprintf("container %q image %q is not from registry.example.com", name, image))
+		}
+	}
+	return out
+})
+
+func sortedKeys[v any](m map[string]v) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestRegisterValidationRule(t *testing.T) {
+	require.NoError(t, RegisterValidationRule("images-from-registry", imagesFromRegistryRule))
+	t.Cleanup(func() {
+		UnregisterValidationRule("images-from-registry")
+	})
+	assert.Equal(t, []string{"images-from-registry"}, RegisteredValidationRules())
+
+	t.Run("duplicate", func(t *testing.T) {
+		assert.EqualError(t, RegisterValidationRule("images-from-registry", imagesFromRegistryRule), "validation rule 'images-from-registry' is already registered")
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		assert.EqualError(t, RegisterValidationRule("", imagesFromRegistryRule), "validation rule name must not be empty")
+	})
+
+	t.Run("nil rule", func(t *testing.T) {
+		assert.EqualError(t, RegisterValidationRule("nil", nil), "validation rule 'nil' must not be nil")
+	})
+
+	t.Run("applied after built-in rules", func(t *testing.T) {
+		workload := workloadWithContainers(types.WorkloadContainers{
+			"a": {Image: "docker.io/busybox", Before: before("a")},
+			"b": {Image: "registry.example.com/busybox"},
+		})
+		err := Validate(workload)
+		require.Error(t, err)
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []string{
+			`container "a" has a self-referencing before entry`,
+			`container "a" image "docker.io/busybox" is not from registry.example.com`,
+		}, validationErr.Messages)
+	})
+
+	t.Run("passing", func(t *testing.T) {
+		workload := workloadWithContainers(types.WorkloadContainers{
+			"a": {Image: "registry.example.com/busybox"},
+		})
+		assert.NoError(t, Validate(workload))
+	})
+
+	assert.True(t, UnregisterValidationRule("images-from-registry"))
+	assert.False(t, UnregisterValidationRule("images-from-registry"))
+	assert.Empty(t, RegisteredValidationRules())
+	assert.NoError(t, Validate(workloadWithContainers(types.WorkloadContainers{"a": {Image: "busybox"}})))
+}
+
+func TestValidateWithRules(t *testing.T) {
+	requireOwner := ValidationRuleFunc(func(workload *types.Workload) []string {
+		annotations, _ := workload.Metadata["annotations"].(map[string]interface{})
+		if _, ok := annotations["owner"]; !ok {
+			return []string{"metadata.annotations.owner is required"}
+		}
+		return nil
+	})
+
+	workload := workloadWithContainers(types.WorkloadContainers{"a": {Image: "busybox"}})
+	assert.NoError(t, Validate(workload))
+	assert.EqualError(t, ValidateWithRules(workload, requireOwner, imagesFromRegistryRule), `validating workload:
+    metadata.annotations.owner is required
+    container "a" image "busybox" is not from registry.example.com`)
+
+	workload.Metadata["annotations"] = map[string]interface{}{"owner": "team-a"}
+	workload.Containers["a"] = types.Container{Image: "registry.example.com/busybox"}
+	assert.NoError(t, ValidateWithRules(workload, requireOwner, imagesFromRegistryRule))
+}
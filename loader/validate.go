@@ -17,6 +17,7 @@ package loader
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/score-spec/score-go/framework"
@@ -124,18 +125,74 @@ func listAllPlaceholders(workload *types.Workload) []string {
 // - A container may not reference itself in a before entry
 //
 // - The before relationships must not contain cycles
+//
+// - Service ports and target ports must be between 1 and 65535
+//
+// - Service port names must be unique after normalization to lowercase with "_" and "." replaced by "-"
+//
+// - Probes must not set both exec and httpGet
+//
+// - HTTP probe ports must be between 1 and 65535 and must not refer to the published port of a service port that
+// maps to a different target port
+//
+// - Container resource requests and limits must be valid cpu and memory quantities
+//
+// - Container resource requests must not exceed the corresponding limits
+//
+// Any rules added through RegisterValidationRule are applied after the
+// built-in rules.
 func Validate(workload *types.Workload) error {
+	return ValidateWithRules(workload)
+}
+
+// ValidateWithRules is like Validate but additionally applies the given rules
+// after the built-in and registered rules. This allows callers to apply a
+// one-off rule set without modifying the global registry.
+func ValidateWithRules(workload *types.Workload, rules ...ValidationRule) error {
 	errMsgs := []string{}
+	for _, rule := range builtinValidationRules {
+		errMsgs = append(errMsgs, rule.Validate(workload)...)
+	}
+	for _, rule := range registeredValidationRules() {
+		errMsgs = append(errMsgs, rule.Validate(workload)...)
+	}
+	for _, rule := range rules {
+		errMsgs = append(errMsgs, rule.Validate(workload)...)
+	}
+
+	if len(errMsgs) > 0 {
+		return &ValidationError{
+			Messages: errMsgs,
+		}
+	}
+	return nil
+}
 
-	// Validate that metadata.name is present and non-empty.
+// builtinValidationRules are the rules always applied by Validate.
+var builtinValidationRules = []ValidationRule{
+	ValidationRuleFunc(validateMetadataName),
+	ValidationRuleFunc(validatePlaceholders),
+	ValidationRuleFunc(validateContainerBefore),
+	ValidationRuleFunc(validateServicePorts),
+	ValidationRuleFunc(validateProbes),
+	ValidationRuleFunc(validateContainerResources),
+}
+
+// validateMetadataName checks that metadata.name is present and non-empty.
+func validateMetadataName(workload *types.Workload) []string {
 	if workload.Metadata == nil {
-		errMsgs = append(errMsgs, "metadata.name is required")
+		return []string{"metadata.name is required"}
 	} else if name, ok := workload.Metadata["name"]; !ok {
-		errMsgs = append(errMsgs, "metadata.name is required")
+		return []string{"metadata.name is required"}
 	} else if nameStr, ok := name.(string); !ok || nameStr == "" {
-		errMsgs = append(errMsgs, "metadata.name must be a non-empty string")
+		return []string{"metadata.name must be a non-empty string"}
 	}
+	return nil
+}
 
+// validatePlaceholders checks that all placeholders are well formed and resolve to a known resource.
+func validatePlaceholders(workload *types.Workload) []string {
+	errMsgs := []string{}
 	placeholders := listAllPlaceholders(workload)
 	for _, placeholder := range placeholders {
 		if !validplaceholderContent.MatchString(placeholder) {
@@ -158,8 +215,12 @@ func Validate(workload *types.Workload) error {
 			errMsgs = append(errMsgs, fmt.Sprintf("placeholder ${%s} has unsupported first element of \"%s\"", placeholder, placeholderParts[0]))
 		}
 	}
+	return errMsgs
+}
 
-	// Validate container before relationships.
+// validateContainerBefore checks that the container before relationships refer to known containers and are acyclic.
+func validateContainerBefore(workload *types.Workload) []string {
+	errMsgs := []string{}
 	containerNames := make(map[string]struct{}, len(workload.Containers))
 	for name := range workload.Containers {
 		containerNames[name] = struct{}{}
@@ -208,11 +269,178 @@ func Validate(workload *types.Workload) error {
 			break
 		}
 	}
+	return errMsgs
+}
 
-	if len(errMsgs) > 0 {
-		return &ValidationError{
-			Messages: errMsgs,
+// isValidPort returns true if the port number is within the valid tcp/udp range.
+func isValidPort(port int) bool {
+	return port >= 1 && port <= 65535
+}
+
+// normalizePortName normalizes a service port name in the way that implementations commonly convert them into
+// platform specific names.
+func normalizePortName(name string) string {
+	return strings.NewReplacer("_", "-", ".", "-").Replace(strings.ToLower(name))
+}
+
+// validateServicePorts checks that the service ports are in range and that their names are unique after
+// normalization.
+func validateServicePorts(workload *types.Workload) []string {
+	errMsgs := []string{}
+	if workload.Service == nil {
+		return errMsgs
+	}
+	normalizedNames := make(map[string]string, len(workload.Service.Ports))
+	for _, name := range sortedStringKeys(workload.Service.Ports) {
+		port := workload.Service.Ports[name]
+		if !isValidPort(port.Port) {
+			errMsgs = append(errMsgs, fmt.Sprintf("service port %q port %d must be between 1 and 65535", name, port.Port))
+		}
+		if port.TargetPort != nil && !isValidPort(*port.TargetPort) {
+			errMsgs = append(errMsgs, fmt.Sprintf("service port %q targetPort %d must be between 1 and 65535", name, *port.TargetPort))
+		}
+		normalized := normalizePortName(name)
+		if other, exists := normalizedNames[normalized]; exists {
+			errMsgs = append(errMsgs, fmt.Sprintf("service ports %q and %q conflict after normalization to %q", other, name, normalized))
+		} else {
+			normalizedNames[normalized] = name
 		}
 	}
-	return nil
+	return errMsgs
+}
+
+// validateProbes checks the container probes for conflicting probe types and ports that are out of range or refer
+// to a published service port rather than the container port. When the workload declares service ports, http probes
+// must use one of their target ports.
+func validateProbes(workload *types.Workload) []string {
+	errMsgs := []string{}
+	// publishedPorts maps published service ports that differ from their target port to the service port name.
+	publishedPorts := make(map[int]string)
+	targetPorts := make(map[int]bool)
+	if workload.Service != nil {
+		for _, name := range sortedStringKeys(workload.Service.Ports) {
+			port := workload.Service.Ports[name]
+			if port.TargetPort != nil && *port.TargetPort != port.Port {
+				if _, exists := publishedPorts[port.Port]; !exists {
+					publishedPorts[port.Port] = name
+				}
+				targetPorts[*port.TargetPort] = true
+			} else {
+				targetPorts[port.Port] = true
+			}
+		}
+	}
+	for _, containerName := range sortedStringKeys(workload.Containers) {
+		container := workload.Containers[containerName]
+		for _, probe := range []struct {
+			kind  string
+			probe *types.ContainerProbe
+		}{{"livenessProbe", container.LivenessProbe}, {"readinessProbe", container.ReadinessProbe}} {
+			if probe.probe == nil {
+				continue
+			}
+			if probe.probe.Exec != nil && probe.probe.HttpGet != nil {
+				errMsgs = append(errMsgs, fmt.Sprintf("container %q %s must not set both exec and httpGet", containerName, probe.kind))
+			}
+			if hg := probe.probe.HttpGet; hg != nil {
+				if !isValidPort(hg.Port) {
+					errMsgs = append(errMsgs, fmt.Sprintf("container %q %s httpGet port %d must be between 1 and 65535", containerName, probe.kind, hg.Port))
+				} else if name, ok := publishedPorts[hg.Port]; ok && !targetPorts[hg.Port] {
+					errMsgs = append(errMsgs, fmt.Sprintf("container %q %s httpGet port %d refers to the published port of service port %q, probes must use the container targetPort %d", containerName, probe.kind, hg.Port, name, *workload.Service.Ports[name].TargetPort))
+				} else if len(targetPorts) > 0 && !targetPorts[hg.Port] {
+					errMsgs = append(errMsgs, fmt.Sprintf("container %q %s httpGet port %d does not match the targetPort of any service port", containerName, probe.kind, hg.Port))
+				}
+			}
+		}
+	}
+	return errMsgs
+}
+
+// parseContainerResources parses the requests and limits of the container resources. Parse failures are returned as
+// validation messages.
+func parseContainerResources(containerName string, resources *types.ContainerResources) (requestCpu, requestMemory, limitCpu, limitMemory *types.Quantity, errMsgs []string) {
+	if resources == nil {
+		return
+	}
+	parse := func(kind string, rl *types.ResourcesLimits) (cpu, memory *types.Quantity) {
+		if rl == nil {
+			return nil, nil
+		}
+		if rl.Cpu != nil {
+			if q, err := types.ParseQuantity(*rl.Cpu); err != nil {
+				errMsgs = append(errMsgs, fmt.Sprintf("container %q resources.%s.cpu is invalid: %v", containerName, kind, err))
+			} else {
+				cpu = &q
+			}
+		}
+		if rl.Memory != nil {
+			if q, err := types.ParseQuantity(*rl.Memory); err != nil {
+				errMsgs = append(errMsgs, fmt.Sprintf("container %q resources.%s.memory is invalid: %v", containerName, kind, err))
+			} else {
+				memory = &q
+			}
+		}
+		return
+	}
+	requestCpu, requestMemory = parse("requests", resources.Requests)
+	limitCpu, limitMemory = parse("limits", resources.Limits)
+	return
+}
+
+// validateContainerResources checks that container resources are valid quantities and that the requests do not
+// exceed the limits.
+func validateContainerResources(workload *types.Workload) []string {
+	errMsgs := []string{}
+	for _, containerName := range sortedStringKeys(workload.Containers) {
+		requestCpu, requestMemory, limitCpu, limitMemory, parseErrMsgs := parseContainerResources(containerName, workload.Containers[containerName].Resources)
+		errMsgs = append(errMsgs, parseErrMsgs...)
+		if requestCpu != nil && limitCpu != nil && requestCpu.Cmp(*limitCpu) > 0 {
+			errMsgs = append(errMsgs, fmt.Sprintf("container %q resources.requests.cpu %s exceeds resources.limits.cpu %s", containerName, requestCpu, limitCpu))
+		}
+		if requestMemory != nil && limitMemory != nil && requestMemory.Cmp(*limitMemory) > 0 {
+			errMsgs = append(errMsgs, fmt.Sprintf("container %q resources.requests.memory %s exceeds resources.limits.memory %s", containerName, requestMemory, limitMemory))
+		}
+	}
+	return errMsgs
+}
+
+// MaxContainerResourcesRule returns a rule which checks that the cpu and memory requests and limits of every container
+// do not exceed the given maxima. Either maximum may be omitted. An error is returned if a maximum is not a valid
+// quantity. The rule does not report unparseable container resources since these are already reported by Validate.
+func MaxContainerResourcesRule(maximum types.ResourcesLimits) (ValidationRule, error) {
+	maxCpu, maxMemory, err := types.ParseResourceQuantities(maximum)
+	if err != nil {
+		return nil, fmt.Errorf("invalid maximum: %w", err)
+	}
+	return ValidationRuleFunc(func(workload *types.Workload) []string {
+		errMsgs := []string{}
+		for _, containerName := range sortedStringKeys(workload.Containers) {
+			requestCpu, requestMemory, limitCpu, limitMemory, _ := parseContainerResources(containerName, workload.Containers[containerName].Resources)
+			for _, check := range []struct {
+				field   string
+				value   *types.Quantity
+				maximum *types.Quantity
+			}{
+				{"requests.cpu", requestCpu, maxCpu},
+				{"requests.memory", requestMemory, maxMemory},
+				{"limits.cpu", limitCpu, maxCpu},
+				{"limits.memory", limitMemory, maxMemory},
+			} {
+				if check.value != nil && check.maximum != nil && check.value.Cmp(*check.maximum) > 0 {
+					errMsgs = append(errMsgs, fmt.Sprintf("container %q resources.%s %s exceeds the maximum of %s", containerName, check.field, check.value, check.maximum))
+				}
+			}
+		}
+		return errMsgs
+	}), nil
+}
+
+// sortedStringKeys returns the keys of the map in a stable order.
+func sortedStringKeys[v any](m map[string]v) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
 }
@@ -216,6 +216,10 @@ func TestValidatePlaceholders(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			workload := workloadWith(testCase.files, testCase.variables, testCase.volumes, testCase.resources)
+			// the fixture sets both exec and httpGet on the liveness probe which is covered by TestValidateServicePortsAndProbes
+			hello := workload.Containers["hello"]
+			hello.LivenessProbe = &types.ContainerProbe{Exec: hello.LivenessProbe.Exec}
+			workload.Containers["hello"] = hello
 			err := Validate(workload)
 			if len(testCase.errorContains) == 0 {
 				assert.NoError(t, err)
@@ -426,3 +430,205 @@ func TestValidateMetadataName(t *testing.T) {
 	}
 }
 
+func TestValidateServicePortsAndProbes(t *testing.T) {
+	httpProbe := func(port int) *types.ContainerProbe {
+		return &types.ContainerProbe{HttpGet: &types.HttpProbe{Path: "/", Port: port}}
+	}
+	testCases := []struct {
+		name       string
+		ports      types.WorkloadServicePorts
+		containers types.WorkloadContainers
+		messages   []string
+	}{
+		{
+			name: "valid",
+			ports: types.WorkloadServicePorts{
+				"www":     {Port: 80, TargetPort: intRef(8080)},
+				"metrics": {Port: 9090},
+			},
+			containers: types.WorkloadContainers{
+				"a": {Image: "img", LivenessProbe: httpProbe(8080), ReadinessProbe: httpProbe(9090)},
+				"b": {Image: "img", LivenessProbe: httpProbe(8080)},
+			},
+		},
+		{
+			name:       "no service ports",
+			containers: types.WorkloadContainers{"a": {Image: "img", LivenessProbe: httpProbe(3000)}},
+		},
+		{
+			name:  "probe port is not a target port",
+			ports: types.WorkloadServicePorts{"www": {Port: 80, TargetPort: intRef(8080)}},
+			containers: types.WorkloadContainers{
+				"a": {Image: "img", LivenessProbe: httpProbe(9999)},
+			},
+			messages: []string{`container "a" livenessProbe httpGet port 9999 does not match the targetPort of any service port`},
+		},
+		{
+			name: "ports out of range",
+			ports: types.WorkloadServicePorts{
+				"a": {Port: 0},
+				"b": {Port: 80, TargetPort: intRef(70000)},
+			},
+			containers: types.WorkloadContainers{
+				"a": {Image: "img", LivenessProbe: httpProbe(-1)},
+			},
+			messages: []string{
+				`service port "a" port 0 must be between 1 and 65535`,
+				`service port "b" targetPort 70000 must be between 1 and 65535`,
+				`container "a" livenessProbe httpGet port -1 must be between 1 and 65535`,
+			},
+		},
+		{
+			name: "normalized name conflicts",
+			ports: types.WorkloadServicePorts{
+				"web_port": {Port: 80},
+				"web-port": {Port: 81},
+				"Web.Port": {Port: 82},
+			},
+			containers: types.WorkloadContainers{"a": {Image: "img"}},
+			messages: []string{
+				`service ports "Web.Port" and "web-port" conflict after normalization to "web-port"`,
+				`service ports "Web.Port" and "web_port" conflict after normalization to "web-port"`,
+			},
+		},
+		{
+			name: "both exec and httpGet",
+			containers: types.WorkloadContainers{
+				"a": {Image: "img", ReadinessProbe: &types.ContainerProbe{
+					Exec:    &types.ExecProbe{Command: []string{"true"}},
+					HttpGet: &types.HttpProbe{Path: "/", Port: 8080},
+				}},
+			},
+			messages: []string{`container "a" readinessProbe must not set both exec and httpGet`},
+		},
+		{
+			name: "probe refers to published port",
+			ports: types.WorkloadServicePorts{
+				"www": {Port: 80, TargetPort: intRef(8080)},
+			},
+			containers: types.WorkloadContainers{
+				"a": {Image: "img", LivenessProbe: httpProbe(80)},
+			},
+			messages: []string{`container "a" livenessProbe httpGet port 80 refers to the published port of service port "www", probes must use the container targetPort 8080`},
+		},
+		{
+			name: "published port is also a target port",
+			ports: types.WorkloadServicePorts{
+				"www":   {Port: 80, TargetPort: intRef(8080)},
+				"plain": {Port: 8000, TargetPort: intRef(80)},
+			},
+			containers: types.WorkloadContainers{
+				"a": {Image: "img", LivenessProbe: httpProbe(80)},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			workload := workloadWithContainers(testCase.containers)
+			if testCase.ports != nil {
+				workload.Service = &types.WorkloadService{Ports: testCase.ports}
+			}
+			err := Validate(workload)
+			if len(testCase.messages) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, testCase.messages, validationErr.Messages)
+		})
+	}
+}
+
+func TestValidateContainerResources(t *testing.T) {
+	resources := func(requestCpu, requestMemory, limitCpu, limitMemory string) *types.ContainerResources {
+		orNil := func(s string) *string {
+			if s == "" {
+				return nil
+			}
+			return stringRef(s)
+		}
+		return &types.ContainerResources{
+			Requests: &types.ResourcesLimits{Cpu: orNil(requestCpu), Memory: orNil(requestMemory)},
+			Limits:   &types.ResourcesLimits{Cpu: orNil(limitCpu), Memory: orNil(limitMemory)},
+		}
+	}
+	testCases := []struct {
+		name       string
+		containers types.WorkloadContainers
+		messages   []string
+	}{
+		{
+			name: "valid",
+			containers: types.WorkloadContainers{
+				"a": {Image: "img", Resources: resources("250m", "1Gi", "0.5", "1.5Gi")},
+				"b": {Image: "img", Resources: resources("1", "1G", "1000m", "1e9")},
+				"c": {Image: "img", Resources: resources("1", "", "", "1Gi")},
+				"d": {Image: "img", Resources: &types.ContainerResources{}},
+			},
+		},
+		{
+			name: "invalid quantities",
+			containers: types.WorkloadContainers{
+				"a": {Image: "img", Resources: resources("one", "10 Gi", "", "")},
+				"b": {Image: "img", Resources: resources("", "", "2 cpus", "1GB")},
+			},
+			messages: []string{
+				`container "a" resources.requests.cpu is invalid: quantity 'one' is not a number with an optional unit`,
+				`container "a" resources.requests.memory is invalid: quantity '10 Gi' has an unknown unit ' Gi'`,
+				`container "b" resources.limits.cpu is invalid: quantity '2 cpus' has an unknown unit ' cpus'`,
+				`container "b" resources.limits.memory is invalid: quantity '1GB' has an unknown unit 'GB'`,
+			},
+		},
+		{
+			name: "requests exceed limits",
+			containers: types.WorkloadContainers{
+				"a": {Image: "img", Resources: resources("1000m", "10Gi", "0.24", "128M")},
+			},
+			messages: []string{
+				`container "a" resources.requests.cpu 1 exceeds resources.limits.cpu 240m`,
+				`container "a" resources.requests.memory 10Gi exceeds resources.limits.memory 128M`,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := Validate(workloadWithContainers(testCase.containers))
+			if len(testCase.messages) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, testCase.messages, validationErr.Messages)
+		})
+	}
+}
+
+func TestMaxContainerResourcesRule(t *testing.T) {
+	_, err := MaxContainerResourcesRule(types.ResourcesLimits{Cpu: stringRef("lots")})
+	assert.EqualError(t, err, "invalid maximum: cpu: quantity 'lots' is not a number with an optional unit")
+
+	rule, err := MaxContainerResourcesRule(types.ResourcesLimits{Cpu: stringRef("2"), Memory: stringRef("4Gi")})
+	require.NoError(t, err)
+	workload := workloadWithContainers(types.WorkloadContainers{
+		"a": {Image: "img", Resources: &types.ContainerResources{
+			Requests: &types.ResourcesLimits{Cpu: stringRef("500m"), Memory: stringRef("1Gi")},
+			Limits:   &types.ResourcesLimits{Cpu: stringRef("2"), Memory: stringRef("4Gi")},
+		}},
+		"b": {Image: "img"},
+	})
+	assert.NoError(t, ValidateWithRules(workload, rule))
+
+	workload.Containers["b"] = types.Container{Image: "img", Resources: &types.ContainerResources{
+		Requests: &types.ResourcesLimits{Cpu: stringRef("3"), Memory: stringRef("1Gi")},
+		Limits:   &types.ResourcesLimits{Cpu: stringRef("4"), Memory: stringRef("8Gi")},
+	}}
+	var validationErr *ValidationError
+	require.ErrorAs(t, ValidateWithRules(workload, rule), &validationErr)
+	assert.Equal(t, []string{
+		`container "b" resources.requests.cpu 3 exceeds the maximum of 2`,
+		`container "b" resources.limits.cpu 4 exceeds the maximum of 2`,
+		`container "b" resources.limits.memory 8Gi exceeds the maximum of 4Gi`,
+	}, validationErr.Messages)
+}
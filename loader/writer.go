@@ -0,0 +1,217 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/score-spec/score-go/types"
+)
+
+// canonicalKeyOrder lists the preferred order of mapping keys by path pattern, where "*" matches any user chosen name.
+// Keys that are not listed are sorted alphabetically after the listed keys.
+var canonicalKeyOrder = []struct {
+	pattern []string
+	keys    []string
+}{
+	{pattern: []string{}, keys: []string{"apiVersion", "metadata", "service", "containers", "resources"}},
+	{pattern: []string{"metadata"}, keys: []string{"name"}},
+	{pattern: []string{"service", "ports", "*"}, keys: []string{"port", "targetPort", "protocol"}},
+	{pattern: []string{"containers", "*"}, keys: []string{
+		"image", "command", "args", "variables", "files", "volumes", "resources", "livenessProbe", "readinessProbe", "before",
+	}},
+	{pattern: []string{"containers", "*", "files", "*"}, keys: []string{"mode", "source", "content", "binaryContent", "noExpand"}},
+	{pattern: []string{"containers", "*", "volumes", "*"}, keys: []string{"source", "path", "readOnly"}},
+	{pattern: []string{"containers", "*", "resources"}, keys: []string{"requests", "limits"}},
+	{pattern: []string{"containers", "*", "resources", "*"}, keys: []string{"cpu", "memory"}},
+	{pattern: []string{"containers", "*", "*", "httpGet"}, keys: []string{"scheme", "host", "port", "path", "httpHeaders"}},
+	{pattern: []string{"resources", "*"}, keys: []string{"type", "class", "id", "metadata", "params"}},
+}
+
+// MarshalWorkload encodes the workload as canonical Score yaml. Keys are written in a deterministic order with the
+// top level sections as apiVersion, metadata, service, containers, and resources.
+func MarshalWorkload(workload *types.Workload) ([]byte, error) {
+	node, err := workloadNode(workload)
+	if err != nil {
+		return nil, err
+	}
+	return encodeNode(node)
+}
+
+// MarshalWorkloadPreserving encodes the workload as Score yaml while preserving the comments, key order, and styles of
+// the original yaml source. This is useful when re-emitting a modified spec: values that have not changed keep their
+// original formatting, new keys are added in canonical order, and removed keys are dropped.
+func MarshalWorkloadPreserving(workload *types.Workload, original []byte) ([]byte, error) {
+	node, err := preservingWorkloadNode(workload, original)
+	if err != nil {
+		return nil, err
+	}
+	return encodeNode(node)
+}
+
+// preservingWorkloadNode converts the workload into a yaml node merged with the comments and styles of the original.
+func preservingWorkloadNode(workload *types.Workload, original []byte) (*yaml.Node, error) {
+	node, err := workloadNode(workload)
+	if err != nil {
+		return nil, err
+	}
+	var originalDoc yaml.Node
+	if err := yaml.Unmarshal(original, &originalDoc); err != nil {
+		return nil, fmt.Errorf("failed to decode original yaml: %w", err)
+	}
+	if originalDoc.Kind == yaml.DocumentNode && len(originalDoc.Content) == 1 {
+		node = mergeNodes(node, originalDoc.Content[0])
+		node = &yaml.Node{
+			Kind: yaml.DocumentNode, Content: []*yaml.Node{node},
+			HeadComment: originalDoc.HeadComment, LineComment: originalDoc.LineComment, FootComment: originalDoc.FootComment,
+		}
+	}
+	return node, nil
+}
+
+// workloadNode converts the workload into a yaml node with canonically ordered keys.
+func workloadNode(workload *types.Workload) (*yaml.Node, error) {
+	if workload == nil {
+		return nil, fmt.Errorf("workload is nil")
+	}
+	node := new(yaml.Node)
+	if err := node.Encode(workload); err != nil {
+		return nil, fmt.Errorf("failed to encode workload: %w", err)
+	}
+	sortNode(node, []string{})
+	return node, nil
+}
+
+func encodeNode(node *yaml.Node) ([]byte, error) {
+	buff := new(bytes.Buffer)
+	encoder := yaml.NewEncoder(buff)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, fmt.Errorf("failed to encode yaml: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode yaml: %w", err)
+	}
+	return buff.Bytes(), nil
+}
+
+func matchesPattern(path, pattern []string) bool {
+	if len(path) != len(pattern) {
+		return false
+	}
+	for i, p := range pattern {
+		if p != "*" && p != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sortNode recursively orders the keys of mapping nodes using canonicalKeyOrder.
+func sortNode(node *yaml.Node, path []string) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, c := range node.Content {
+			sortNode(c, path)
+		}
+	case yaml.SequenceNode:
+		for _, c := range node.Content {
+			sortNode(c, path)
+		}
+	case yaml.MappingNode:
+		rank := make(map[string]int)
+		for _, o := range canonicalKeyOrder {
+			if matchesPattern(path, o.pattern) {
+				for i, k := range o.keys {
+					rank[k] = i + 1
+				}
+			}
+		}
+		pairs := make([][2]*yaml.Node, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			pairs = append(pairs, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+		}
+		sort.SliceStable(pairs, func(i, j int) bool {
+			ri, rj := rank[pairs[i][0].Value], rank[pairs[j][0].Value]
+			if ri != 0 && rj != 0 {
+				return ri < rj
+			} else if ri != 0 || rj != 0 {
+				return ri != 0
+			}
+			return pairs[i][0].Value < pairs[j][0].Value
+		})
+		node.Content = node.Content[:0]
+		for _, p := range pairs {
+			node.Content = append(node.Content, p[0], p[1])
+			childPath := make([]string, len(path), len(path)+1)
+			copy(childPath, path)
+			sortNode(p[1], append(childPath, p[0].Value))
+		}
+	}
+}
+
+// mergeNodes returns the updated node with the comments and styles of the original node where the structure matches.
+// Unchanged scalar values keep the original node so that quoting and formatting are retained.
+func mergeNodes(updated, original *yaml.Node) *yaml.Node {
+	if original.Kind == yaml.AliasNode && original.Alias != nil {
+		original = original.Alias
+	}
+	if updated.Kind != original.Kind {
+		return updated
+	}
+	switch updated.Kind {
+	case yaml.ScalarNode:
+		if updated.Value == original.Value && updated.ShortTag() == original.ShortTag() {
+			return original
+		}
+	case yaml.SequenceNode:
+		for i := range updated.Content {
+			if i < len(original.Content) {
+				updated.Content[i] = mergeNodes(updated.Content[i], original.Content[i])
+			}
+		}
+	case yaml.MappingNode:
+		originalIndex := make(map[string]int, len(original.Content)/2)
+		for i := 0; i+1 < len(original.Content); i += 2 {
+			originalIndex[original.Content[i].Value] = i
+		}
+		kept := make([]*yaml.Node, 0, len(updated.Content))
+		added := make([]*yaml.Node, 0)
+		for i := 0; i+1 < len(updated.Content); i += 2 {
+			if _, ok := originalIndex[updated.Content[i].Value]; !ok {
+				added = append(added, updated.Content[i], updated.Content[i+1])
+			}
+		}
+		// existing keys keep their original order
+		for i := 0; i+1 < len(original.Content); i += 2 {
+			for j := 0; j+1 < len(updated.Content); j += 2 {
+				if updated.Content[j].Value == original.Content[i].Value {
+					kept = append(kept, original.Content[i], mergeNodes(updated.Content[j+1], original.Content[i+1]))
+					break
+				}
+			}
+		}
+		updated.Content = append(kept, added...)
+	}
+	updated.Style = original.Style
+	updated.HeadComment = original.HeadComment
+	updated.LineComment = original.LineComment
+	updated.FootComment = original.FootComment
+	return updated
+}
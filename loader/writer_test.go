@@ -0,0 +1,166 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/score-spec/score-go/types"
+)
+
+func TestMarshalWorkload(t *testing.T) {
+	tcp := types.ServicePortProtocolTCP
+	workload := &types.Workload{
+		ApiVersion: "score.dev/v1b1",
+		Metadata:   types.WorkloadMetadata{"name": "example", "annotations": map[string]interface{}{"b": "2", "a": "1"}},
+		Containers: types.WorkloadContainers{
+			"main": {
+				Image:     "nginx",
+				Args:      []string{"-c", "conf"},
+				Variables: types.ContainerVariables{"B": "2", "A": "1"},
+				Files: types.ContainerFiles{
+					"/etc/conf": {Content: stringRef("a\nb\n"), Mode: stringRef("0644")},
+				},
+				Resources: &types.ContainerResources{
+					Limits:   &types.ResourcesLimits{Memory: stringRef("1Gi"), Cpu: stringRef("1")},
+					Requests: &types.ResourcesLimits{Cpu: stringRef("500m")},
+				},
+			},
+		},
+		Resources: types.WorkloadResources{
+			"db": {Type: "postgres", Params: types.ResourceParams{"size": 2}, Class: stringRef("large")},
+		},
+		Service: &types.WorkloadService{Ports: types.WorkloadServicePorts{
+			"web": {Protocol: &tcp, TargetPort: intRef(8080), Port: 80},
+		}},
+	}
+	raw, err := MarshalWorkload(workload)
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: score.dev/v1b1
+metadata:
+  name: example
+  annotations:
+    a: "1"
+    b: "2"
+service:
+  ports:
+    web:
+      port: 80
+      targetPort: 8080
+      protocol: TCP
+containers:
+  main:
+    image: nginx
+    args:
+      - -c
+      - conf
+    variables:
+      A: "1"
+      B: "2"
+    files:
+      /etc/conf:
+        mode: "0644"
+        content: |
+          a
+          b
+    resources:
+      requests:
+        cpu: 500m
+      limits:
+        cpu: "1"
+        memory: 1Gi
+resources:
+  db:
+    type: postgres
+    class: large
+    params:
+      size: 2
+`, string(raw))
+
+	var decoded types.Workload
+	require.NoError(t, yaml.Unmarshal(raw, &decoded))
+	assert.True(t, workload.Equal(&decoded))
+
+	again, err := MarshalWorkload(&decoded)
+	require.NoError(t, err)
+	assert.Equal(t, string(raw), string(again), "output must be deterministic")
+
+	_, err = MarshalWorkload(nil)
+	assert.EqualError(t, err, "workload is nil")
+}
+
+func TestMarshalWorkloadPreserving(t *testing.T) {
+	original := `# The example workload
+apiVersion: score.dev/v1b1
+metadata:
+  name: example # the workload name
+containers:
+  main:
+    # pinned for now
+    image: 'nginx:1.2'
+    args: ["-c", "conf"]
+    variables:
+      OLD: value
+  sidecar:
+    image: envoy
+resources:
+  db:
+    type: postgres # needs to be 16+
+`
+	var workload types.Workload
+	require.NoError(t, yaml.Unmarshal([]byte(original), &workload))
+
+	main := workload.Containers["main"]
+	main.Image = "nginx:1.3"
+	main.Variables = types.ContainerVariables{"NEW": "value"}
+	workload.Containers["main"] = main
+	delete(workload.Containers, "sidecar")
+	workload.Resources["cache"] = types.Resource{Type: "redis"}
+
+	raw, err := MarshalWorkloadPreserving(&workload, []byte(original))
+	require.NoError(t, err)
+	assert.Equal(t, `# The example workload
+apiVersion: score.dev/v1b1
+metadata:
+  name: example # the workload name
+containers:
+  main:
+    # pinned for now
+    image: 'nginx:1.3'
+    args: ["-c", "conf"]
+    variables:
+      NEW: value
+resources:
+  db:
+    type: postgres # needs to be 16+
+  cache:
+    type: redis
+`, string(raw))
+
+	unchanged, err := MarshalWorkloadPreserving(&types.Workload{
+		ApiVersion: "score.dev/v1b1",
+		Metadata:   types.WorkloadMetadata{"name": "example"},
+		Containers: types.WorkloadContainers{"main": {Image: "nginx:1.2", Args: []string{"-c", "conf"}}},
+	}, []byte("apiVersion: score.dev/v1b1\nmetadata: {name: example}\ncontainers:\n  main:\n    image: \"nginx:1.2\"\n    args: [\"-c\", \"conf\"]\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "apiVersion: score.dev/v1b1\nmetadata: {name: example}\ncontainers:\n  main:\n    image: \"nginx:1.2\"\n    args: [\"-c\", \"conf\"]\n", string(unchanged))
+
+	_, err = MarshalWorkloadPreserving(&workload, []byte("{"))
+	assert.ErrorContains(t, err, "failed to decode original yaml")
+}
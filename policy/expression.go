@@ -0,0 +1,662 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/score-spec/score-go/framework"
+)
+
+// Expression is a compiled predicate expression. See CompileExpression for the supported syntax.
+type Expression struct {
+	source string
+	root   node
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// CompileExpression compiles a predicate expression. The language is intentionally small:
+//
+// - literals: "string", 'string', numbers, true, false, null
+//
+// - field access: name, name.sub, name["key with.dots"], list[0]. Fields are resolved relative to the current node.
+// The identifier $ refers to the root of the workload and @ refers to the current node explicitly. Missing fields
+// evaluate to null.
+//
+// - comparison: ==, !=, <, <=, >, >=
+//
+// - boolean logic: &&, ||, ! and parentheses
+//
+// - functions: exists(x), len(x), lower(s), startsWith(s, prefix), endsWith(s, suffix), contains(s|list|map, x),
+// matches(s, regex), resource(s) which resolves a ${resources.<name>} placeholder to the named workload resource.
+func CompileExpression(source string) (*Expression, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s at offset %d", t, t.pos)
+	}
+	return &Expression{source: source, root: root}, nil
+}
+
+// evalContext holds the root document and the current node during evaluation.
+type evalContext struct {
+	root    interface{}
+	current interface{}
+}
+
+// eval evaluates the expression with the given root document and current node. Documents are the generic decoded
+// json form of the workload.
+func (e *Expression) eval(ctx *evalContext) (interface{}, error) {
+	return e.root.eval(ctx)
+}
+
+// evalBool evaluates the expression and requires the result to be a boolean. A null result is treated as false.
+func (e *Expression) evalBool(ctx *evalContext) (bool, error) {
+	v, err := e.eval(ctx)
+	if err != nil {
+		return false, err
+	}
+	return toBool(v)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("'%s'", t.value)
+}
+
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", "[", "]", ".", ","}
+
+func isIdentStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_' || r == '$' || r == '@'
+}
+
+func isIdentPart(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-'
+}
+
+func tokenize(source string) ([]token, error) {
+	var out []token
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			start := i
+			sb := new(strings.Builder)
+			i++
+			for ; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at offset %d", start)
+			}
+			i++
+			out = append(out, token{kind: tokenString, value: sb.String(), pos: start})
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			out = append(out, token{kind: tokenNumber, value: string(runes[start:i]), pos: start})
+		case isIdentStart(r):
+			start := i
+			i++
+			if r != '$' && r != '@' {
+				for i < len(runes) && isIdentPart(runes[i]) {
+					i++
+				}
+			}
+			out = append(out, token{kind: tokenIdent, value: string(runes[start:i]), pos: start})
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					out = append(out, token{kind: tokenOperator, value: op, pos: i})
+					i += len([]rune(op))
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character '%c' at offset %d", r, i)
+			}
+		}
+	}
+	return append(out, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOperator(values ...string) bool {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return false
+	}
+	for _, v := range values {
+		if t.value == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	if !p.isOperator(op) {
+		t := p.peek()
+		return fmt.Errorf("expected '%s' but found %s at offset %d", op, t, t.pos)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("&&") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOperator("!") {
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{inner: inner}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+	if p.isOperator("==", "!=", "<", "<=", ">", ">=") {
+		op := p.next().value
+		right, err := p.parsePostfix()
+		if err != nil {
+			return nil, err
+		}
+		return &compareNode{op: op, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if p.isOperator(".") {
+			p.next()
+			t := p.next()
+			if t.kind != tokenIdent || t.value == "$" || t.value == "@" {
+				return nil, fmt.Errorf("expected field name but found %s at offset %d", t, t.pos)
+			}
+			n = &indexNode{target: n, key: &literalNode{value: t.value}}
+		} else if p.isOperator("[") {
+			p.next()
+			key, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			n = &indexNode{target: n, key: key}
+		} else {
+			return n, nil
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString:
+		return &literalNode{value: t.value}, nil
+	case tokenNumber:
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s' at offset %d", t.value, t.pos)
+		}
+		return &literalNode{value: f}, nil
+	case tokenIdent:
+		switch t.value {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		case "$":
+			return &rootNode{}, nil
+		case "@":
+			return &currentNode{}, nil
+		}
+		if p.isOperator("(") {
+			return p.parseCall(t)
+		}
+		return &indexNode{target: &currentNode{}, key: &literalNode{value: t.value}}, nil
+	case tokenOperator:
+		if t.value == "(" {
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %s at offset %d", t, t.pos)
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	fn, ok := functions[name.value]
+	if !ok {
+		return nil, fmt.Errorf("unknown function '%s' at offset %d", name.value, name.pos)
+	}
+	_ = p.next()
+	var args []node
+	for !p.isOperator(")") {
+		if len(args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	_ = p.next()
+	if len(args) != fn.arity {
+		return nil, fmt.Errorf("function '%s' expects %d arguments but got %d", name.value, fn.arity, len(args))
+	}
+	return &callNode{name: name.value, fn: fn.impl, args: args}, nil
+}
+
+type node interface {
+	eval(ctx *evalContext) (interface{}, error)
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) eval(*evalContext) (interface{}, error) {
+	return n.value, nil
+}
+
+type rootNode struct{}
+
+func (n *rootNode) eval(ctx *evalContext) (interface{}, error) {
+	return ctx.root, nil
+}
+
+type currentNode struct{}
+
+func (n *currentNode) eval(ctx *evalContext) (interface{}, error) {
+	return ctx.current, nil
+}
+
+type indexNode struct {
+	target node
+	key    node
+}
+
+func (n *indexNode) eval(ctx *evalContext) (interface{}, error) {
+	target, err := n.target.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key, err := n.key.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch typed := target.(type) {
+	case map[string]interface{}:
+		k, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot index map with %v", key)
+		}
+		return typed[k], nil
+	case []interface{}:
+		f, ok := key.(float64)
+		if !ok {
+			return nil, fmt.Errorf("cannot index list with %v", key)
+		}
+		if i := int(f); i >= 0 && i < len(typed) {
+			return typed[i], nil
+		}
+	}
+	return nil, nil
+}
+
+type notNode struct {
+	inner node
+}
+
+func (n *notNode) eval(ctx *evalContext) (interface{}, error) {
+	v, err := n.inner.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b, err := toBool(v)
+	if err != nil {
+		return nil, err
+	}
+	return !b, nil
+}
+
+type logicalNode struct {
+	op          string
+	left, right node
+}
+
+func (n *logicalNode) eval(ctx *evalContext) (interface{}, error) {
+	lv, err := n.left.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l, err := toBool(lv)
+	if err != nil {
+		return nil, err
+	}
+	if (n.op == "&&" && !l) || (n.op == "||" && l) {
+		return l, nil
+	}
+	rv, err := n.right.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toBool(rv)
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+func (n *compareNode) eval(ctx *evalContext) (interface{}, error) {
+	l, err := n.left.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r, err := n.right.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "==":
+		return reflect.DeepEqual(l, r), nil
+	case "!=":
+		return !reflect.DeepEqual(l, r), nil
+	}
+	var c int
+	switch lt := l.(type) {
+	case float64:
+		rt, ok := r.(float64)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare %v %s %v", l, n.op, r)
+		}
+		c = compareOrdered(lt, rt)
+	case string:
+		rt, ok := r.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare %v %s %v", l, n.op, r)
+		}
+		c = compareOrdered(lt, rt)
+	default:
+		return nil, fmt.Errorf("cannot compare %v %s %v", l, n.op, r)
+	}
+	switch n.op {
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	default:
+		return c >= 0, nil
+	}
+}
+
+func compareOrdered[k float64 | string](a, b k) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+type callNode struct {
+	name string
+	fn   func(ctx *evalContext, args []interface{}) (interface{}, error)
+	args []node
+}
+
+func (n *callNode) eval(ctx *evalContext) (interface{}, error) {
+	args := make([]interface{}, len(n.args))
+	for i, a := range n.args {
+		v, err := a.eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	v, err := n.fn(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", n.name, err)
+	}
+	return v, nil
+}
+
+func toBool(v interface{}) (bool, error) {
+	switch typed := v.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return typed, nil
+	default:
+		return false, fmt.Errorf("expected a boolean but got %v", v)
+	}
+}
+
+func stringArgs(args []interface{}) ([]string, bool) {
+	out := make([]string, len(args))
+	for i, a := range args {
+		s, ok := a.(string)
+		if !ok {
+			return nil, false
+		}
+		out[i] = s
+	}
+	return out, true
+}
+
+type function struct {
+	arity int
+	impl  func(ctx *evalContext, args []interface{}) (interface{}, error)
+}
+
+// stringPredicate builds a two-argument function over strings which evaluates to false for non-string arguments.
+func stringPredicate(f func(a, b string) bool) function {
+	return function{arity: 2, impl: func(_ *evalContext, args []interface{}) (interface{}, error) {
+		s, ok := stringArgs(args)
+		if !ok {
+			return false, nil
+		}
+		return f(s[0], s[1]), nil
+	}}
+}
+
+var functions = map[string]function{
+	"exists": {arity: 1, impl: func(_ *evalContext, args []interface{}) (interface{}, error) {
+		return args[0] != nil, nil
+	}},
+	"len": {arity: 1, impl: func(_ *evalContext, args []interface{}) (interface{}, error) {
+		switch typed := args[0].(type) {
+		case nil:
+			return float64(0), nil
+		case string:
+			return float64(len(typed)), nil
+		case []interface{}:
+			return float64(len(typed)), nil
+		case map[string]interface{}:
+			return float64(len(typed)), nil
+		default:
+			return nil, fmt.Errorf("unsupported argument %v", args[0])
+		}
+	}},
+	"lower": {arity: 1, impl: func(_ *evalContext, args []interface{}) (interface{}, error) {
+		if s, ok := args[0].(string); ok {
+			return strings.ToLower(s), nil
+		}
+		return args[0], nil
+	}},
+	"startsWith": stringPredicate(strings.HasPrefix),
+	"endsWith":   stringPredicate(strings.HasSuffix),
+	"contains": {arity: 2, impl: func(_ *evalContext, args []interface{}) (interface{}, error) {
+		switch typed := args[0].(type) {
+		case string:
+			sub, ok := args[1].(string)
+			return ok && strings.Contains(typed, sub), nil
+		case []interface{}:
+			for _, item := range typed {
+				if reflect.DeepEqual(item, args[1]) {
+					return true, nil
+				}
+			}
+		case map[string]interface{}:
+			if k, ok := args[1].(string); ok {
+				_, ok = typed[k]
+				return ok, nil
+			}
+		}
+		return false, nil
+	}},
+	"matches": {arity: 2, impl: func(_ *evalContext, args []interface{}) (interface{}, error) {
+		s, ok := stringArgs(args)
+		if !ok {
+			return false, nil
+		}
+		re, err := regexp.Compile(s[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %w", err)
+		}
+		return re.MatchString(s[0]), nil
+	}},
+	"resource": {arity: 1, impl: func(ctx *evalContext, args []interface{}) (interface{}, error) {
+		s, ok := args[0].(string)
+		if !ok || !strings.HasPrefix(s, "${") || !strings.HasSuffix(s, "}") {
+			return nil, nil
+		}
+		parts := framework.SplitRefParts(s[2 : len(s)-1])
+		if len(parts) < 2 || parts[0] != "resources" {
+			return nil, nil
+		}
+		root, _ := ctx.root.(map[string]interface{})
+		resources, _ := root["resources"].(map[string]interface{})
+		return resources[parts[1]], nil
+	}},
+}
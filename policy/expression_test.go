@@ -0,0 +1,111 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpression(t *testing.T) {
+	root := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "example"},
+		"resources": map[string]interface{}{
+			"shared": map[string]interface{}{"type": "volume", "id": "shared-vol"},
+			"local":  map[string]interface{}{"type": "volume"},
+		},
+	}
+	current := map[string]interface{}{
+		"image":    "registry.example.com/app:1.2",
+		"readOnly": false,
+		"source":   "${resources.shared}",
+		"port":     float64(8080),
+		"args":     []interface{}{"-v", "--debug"},
+		"key.with": map[string]interface{}{"dots": "x"},
+	}
+
+	for _, tc := range []struct {
+		expr    string
+		expect  interface{}
+		wantErr string
+	}{
+		{expr: `true`, expect: true},
+		{expr: `null`, expect: nil},
+		{expr: `'single' == "single"`, expect: true},
+		{expr: `port == 8080`, expect: true},
+		{expr: `port >= 1024 && port < 65536`, expect: true},
+		{expr: `port > -1`, expect: true},
+		{expr: `!readOnly`, expect: true},
+		{expr: `readOnly == false || missing`, expect: true},
+		{expr: `missing == null`, expect: true},
+		{expr: `!(missing.deeper)`, expect: true},
+		{expr: `@.image == image`, expect: true},
+		{expr: `$.metadata.name`, expect: "example"},
+		{expr: `args[1]`, expect: "--debug"},
+		{expr: `args[5]`, expect: nil},
+		{expr: `@["key.with"].dots`, expect: "x"},
+		{expr: `exists(image) && !exists(nothing)`, expect: true},
+		{expr: `len(args) == 2 && len(nothing) == 0`, expect: true},
+		{expr: `startsWith(image, "registry.example.com/")`, expect: true},
+		{expr: `endsWith(image, ":latest")`, expect: false},
+		{expr: `contains(args, "--debug") && contains(image, "app") && contains($.resources, "local")`, expect: true},
+		{expr: `matches(image, ":[0-9.]+$")`, expect: true},
+		{expr: `lower("ABC") == "abc"`, expect: true},
+		{expr: `resource(source).id`, expect: "shared-vol"},
+		{expr: `resource("${resources.local}").id`, expect: nil},
+		{expr: `resource("not a placeholder")`, expect: nil},
+		{expr: `image > 1`, wantErr: "cannot compare registry.example.com/app:1.2 > 1"},
+		{expr: `image && true`, wantErr: "expected a boolean but got registry.example.com/app:1.2"},
+		{expr: `matches(image, "(")`, wantErr: "matches: invalid regular expression"},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			e, err := CompileExpression(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expr, e.String())
+			v, err := e.eval(&evalContext{root: root, current: current})
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, v)
+		})
+	}
+}
+
+func TestCompileExpression_errors(t *testing.T) {
+	for _, tc := range []struct {
+		expr    string
+		wantErr string
+	}{
+		{expr: ``, wantErr: "unexpected end of expression at offset 0"},
+		{expr: `a ==`, wantErr: "unexpected end of expression at offset 4"},
+		{expr: `"abc`, wantErr: "unterminated string at offset 0"},
+		{expr: `a # b`, wantErr: "unexpected character '#' at offset 2"},
+		{expr: `(a`, wantErr: "expected ')' but found end of expression at offset 2"},
+		{expr: `a b`, wantErr: "unexpected 'b' at offset 2"},
+		{expr: `a.$`, wantErr: "expected field name but found '$' at offset 2"},
+		{expr: `unknown(a)`, wantErr: "unknown function 'unknown' at offset 0"},
+		{expr: `exists(a, b)`, wantErr: "function 'exists' expects 1 arguments but got 2"},
+		{expr: `1.2.3 == a`, wantErr: "invalid number '1.2.3' at offset 0"},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			_, err := CompileExpression(tc.expr)
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
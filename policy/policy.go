@@ -0,0 +1,324 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy provides declarative policies that are evaluated against Score workloads. A policy file contains a
+// list of rules, each of which selects a set of nodes within the workload and asserts a predicate expression against
+// each of them. This allows organisations to maintain workload constraints as data rather than as Go code.
+//
+// An example policy file:
+//
+//	rules:
+//	  - name: no-writable-shared-volumes
+//	    severity: error
+//	    select: containers.*.volumes.*
+//	    when: exists(resource(source).id)
+//	    assert: readOnly == true
+//	    message: volumes from shared resources must be mounted readOnly
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/score-spec/score-go/framework"
+	"github.com/score-spec/score-go/loader"
+	"github.com/score-spec/score-go/types"
+	"github.com/score-spec/score-go/uriget"
+)
+
+// Severity is the severity of a Diagnostic.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// rank orders severities so that they can be compared.
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AtLeast returns true if the severity is the same or more severe than the other severity.
+func (s Severity) AtLeast(other Severity) bool {
+	return s.rank() >= other.rank()
+}
+
+// Diagnostic is a single policy violation found in a workload.
+type Diagnostic struct {
+	// Rule is the name of the rule that produced the diagnostic.
+	Rule string `json:"rule" yaml:"rule"`
+	// Severity is the severity of the rule.
+	Severity Severity `json:"severity" yaml:"severity"`
+	// Path is the .-separated path to the offending node in the workload.
+	Path string `json:"path" yaml:"path"`
+	// Message is the human readable description of the violation.
+	Message string `json:"message" yaml:"message"`
+}
+
+// String returns a string representation of the diagnostic.
+func (d Diagnostic) String() string {
+	if d.Path == "" {
+		return fmt.Sprintf("%s: %s (%s)", d.Severity, d.Message, d.Rule)
+	}
+	return fmt.Sprintf("%s: %s: %s (%s)", d.Severity, d.Path, d.Message, d.Rule)
+}
+
+// Rule is a single declarative policy rule.
+type Rule struct {
+	// Name is the unique name of the rule.
+	Name string `yaml:"name"`
+	// Severity is the severity of the diagnostics produced by this rule. Defaults to error.
+	Severity Severity `yaml:"severity,omitempty"`
+	// Select is a .-separated path of the nodes that the rule applies to. A "*" element matches all entries in a map
+	// or list. Defaults to the workload root.
+	Select string `yaml:"select,omitempty"`
+	// When is an optional expression which must evaluate to true for the rule to apply to a selected node.
+	When string `yaml:"when,omitempty"`
+	// Assert is the expression that must evaluate to true for each selected node.
+	Assert string `yaml:"assert"`
+	// Message is the message to report when the assertion fails. Defaults to a message containing the assertion.
+	Message string `yaml:"message,omitempty"`
+
+	selectParts []string
+	when        *Expression
+	assert      *Expression
+}
+
+// Policy is a set of compiled rules.
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// compile validates and compiles the rule expressions.
+func (r *Rule) compile() error {
+	if r.Name == "" {
+		return fmt.Errorf("name: is required")
+	}
+	switch r.Severity {
+	case "":
+		r.Severity = SeverityError
+	case SeverityError, SeverityWarning, SeverityInfo:
+	default:
+		return fmt.Errorf("rule '%s': severity: must be one of error, warning, or info", r.Name)
+	}
+	if r.Select != "" {
+		r.selectParts = framework.ParseDotPathParts(r.Select)
+	}
+	if r.When != "" {
+		e, err := CompileExpression(r.When)
+		if err != nil {
+			return fmt.Errorf("rule '%s': when: %w", r.Name, err)
+		}
+		r.when = e
+	}
+	if r.Assert == "" {
+		return fmt.Errorf("rule '%s': assert: is required", r.Name)
+	}
+	e, err := CompileExpression(r.Assert)
+	if err != nil {
+		return fmt.Errorf("rule '%s': assert: %w", r.Name, err)
+	}
+	r.assert = e
+	if r.Message == "" {
+		r.Message = "assertion failed: " + r.Assert
+	}
+	return nil
+}
+
+// Parse decodes and compiles a yaml or json policy document.
+func Parse(raw []byte) (*Policy, error) {
+	var p Policy
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("decoding policy: %w", err)
+	}
+	if err := p.compile(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// compile compiles all rules and checks that rule names are unique.
+func (p *Policy) compile() error {
+	seen := make(map[string]bool, len(p.Rules))
+	for i := range p.Rules {
+		if err := p.Rules[i].compile(); err != nil {
+			return fmt.Errorf("rules.%d: %w", i, err)
+		}
+		if seen[p.Rules[i].Name] {
+			return fmt.Errorf("rules.%d: rule '%s' is defined more than once", i, p.Rules[i].Name)
+		}
+		seen[p.Rules[i].Name] = true
+	}
+	return nil
+}
+
+// New compiles the given rules into a policy.
+func New(rules ...Rule) (*Policy, error) {
+	p := &Policy{Rules: rules}
+	if err := p.compile(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Load retrieves one or more policy files using uriget.GetFiles and combines them into a single policy. Rule names
+// must be unique across all files.
+func Load(ctx context.Context, uri string, optionFuncs ...uriget.Option) (*Policy, error) {
+	files, err := uriget.GetFiles(ctx, uri, optionFuncs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load policy from '%s': %w", uri, err)
+	}
+	out := &Policy{}
+	for _, f := range files {
+		p, err := Parse(f.Content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.URI, err)
+		}
+		out.Rules = append(out.Rules, p.Rules...)
+	}
+	if err := out.compile(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// toGeneric converts the workload into its generic json-decoded form for evaluation.
+func toGeneric(workload *types.Workload) (map[string]interface{}, error) {
+	raw, err := json.Marshal(workload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal workload: %w", err)
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workload: %w", err)
+	}
+	return out, nil
+}
+
+type selectedNode struct {
+	path  []string
+	value interface{}
+}
+
+// selectNodes walks the path parts from the given node and returns all matched nodes in a deterministic order.
+func selectNodes(current interface{}, path []string, parts []string) []selectedNode {
+	if len(parts) == 0 {
+		return []selectedNode{{path: path, value: current}}
+	}
+	var out []selectedNode
+	switch typed := current.(type) {
+	case map[string]interface{}:
+		keys := []string{parts[0]}
+		if parts[0] == "*" {
+			keys = make([]string, 0, len(typed))
+			for k := range typed {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+		}
+		for _, k := range keys {
+			if v, ok := typed[k]; ok {
+				out = append(out, selectNodes(v, append(path[:len(path):len(path)], k), parts[1:])...)
+			}
+		}
+	case []interface{}:
+		for i, v := range typed {
+			if k := fmt.Sprint(i); parts[0] == "*" || parts[0] == k {
+				out = append(out, selectNodes(v, append(path[:len(path):len(path)], k), parts[1:])...)
+			}
+		}
+	}
+	return out
+}
+
+// formatPath joins path parts with "." while escaping any "." in the parts.
+func formatPath(parts []string) string {
+	escaped := make([]string, len(parts))
+	for i, p := range parts {
+		escaped[i] = strings.ReplaceAll(p, ".", "\\.")
+	}
+	return strings.Join(escaped, ".")
+}
+
+// Evaluate applies every rule in the policy to the workload and returns the diagnostics in rule order. An error is
+// returned if an expression cannot be evaluated, for example when comparing values of incompatible types.
+func (p *Policy) Evaluate(workload *types.Workload) ([]Diagnostic, error) {
+	root, err := toGeneric(workload)
+	if err != nil {
+		return nil, err
+	}
+	var out []Diagnostic
+	for _, rule := range p.Rules {
+		if rule.assert == nil {
+			return nil, fmt.Errorf("rule '%s': is not compiled, use New, Parse, or Load to construct policies", rule.Name)
+		}
+		for _, n := range selectNodes(root, nil, rule.selectParts) {
+			ctx := &evalContext{root: root, current: n.value}
+			if rule.when != nil {
+				if ok, err := rule.when.evalBool(ctx); err != nil {
+					return nil, fmt.Errorf("rule '%s': %s: when: %w", rule.Name, formatPath(n.path), err)
+				} else if !ok {
+					continue
+				}
+			}
+			if ok, err := rule.assert.evalBool(ctx); err != nil {
+				return nil, fmt.Errorf("rule '%s': %s: assert: %w", rule.Name, formatPath(n.path), err)
+			} else if !ok {
+				out = append(out, Diagnostic{
+					Rule:     rule.Name,
+					Severity: rule.Severity,
+					Path:     formatPath(n.path),
+					Message:  rule.Message,
+				})
+			}
+		}
+	}
+	return out, nil
+}
+
+// ValidationRule adapts the policy into a loader.ValidationRule so that it can be applied through loader.Validate
+// or loader.ValidateWithRules. Only diagnostics at or above the minimum severity are reported. Evaluation errors are
+// reported as validation messages.
+func (p *Policy) ValidationRule(minSeverity Severity) loader.ValidationRule {
+	return loader.ValidationRuleFunc(func(workload *types.Workload) []string {
+		diags, err := p.Evaluate(workload)
+		if err != nil {
+			return []string{fmt.Sprintf("policy evaluation failed: %v", err)}
+		}
+		var out []string
+		for _, d := range diags {
+			if d.Severity.AtLeast(minSeverity) {
+				out = append(out, d.String())
+			}
+		}
+		return out
+	})
+}
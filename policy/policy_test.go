@@ -0,0 +1,143 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/score-spec/score-go/loader"
+	"github.com/score-spec/score-go/types"
+	"github.com/score-spec/score-go/uriget"
+)
+
+func ref[k any](in k) *k {
+	return &in
+}
+
+func exampleWorkload() *types.Workload {
+	return &types.Workload{
+		ApiVersion: "score.dev/v1b1",
+		Metadata:   types.WorkloadMetadata{"name": "example"},
+		Containers: types.WorkloadContainers{
+			"api": {
+				Image: "registry.example.com/api:1.2",
+				Volumes: types.ContainerVolumes{
+					"/mnt/shared": {Source: "${resources.shared}", ReadOnly: ref(false)},
+					"/mnt/local":  {Source: "${resources.local}"},
+				},
+			},
+			"sidecar": {
+				Image: "docker.io/envoy:latest",
+				Volumes: types.ContainerVolumes{
+					"/mnt/shared": {Source: "${resources.shared}", ReadOnly: ref(true)},
+				},
+			},
+		},
+		Resources: types.WorkloadResources{
+			"shared": {Type: "volume", Id: ref("shared-vol")},
+			"local":  {Type: "volume"},
+		},
+	}
+}
+
+const examplePolicy = `
+rules:
+  - name: no-writable-shared-volumes
+    select: containers.*.volumes.*
+    when: exists(resource(source).id)
+    assert: readOnly == true
+    message: volumes from shared resources must be mounted readOnly
+  - name: trusted-registry
+    severity: warning
+    select: containers.*
+    assert: startsWith(image, "registry.example.com/")
+  - name: owner-annotation
+    severity: info
+    assert: exists(metadata.annotations.owner)
+    message: metadata.annotations.owner should be set
+`
+
+func TestEvaluate(t *testing.T) {
+	p, err := Parse([]byte(examplePolicy))
+	require.NoError(t, err)
+	diags, err := p.Evaluate(exampleWorkload())
+	require.NoError(t, err)
+	assert.Equal(t, []Diagnostic{
+		{Rule: "no-writable-shared-volumes", Severity: SeverityError, Path: "containers.api.volumes./mnt/shared", Message: "volumes from shared resources must be mounted readOnly"},
+		{Rule: "trusted-registry", Severity: SeverityWarning, Path: "containers.sidecar", Message: `assertion failed: startsWith(image, "registry.example.com/")`},
+		{Rule: "owner-annotation", Severity: SeverityInfo, Path: "", Message: "metadata.annotations.owner should be set"},
+	}, diags)
+	assert.Equal(t, "error: containers.api.volumes./mnt/shared: volumes from shared resources must be mounted readOnly (no-writable-shared-volumes)", diags[0].String())
+	assert.Equal(t, "info: metadata.annotations.owner should be set (owner-annotation)", diags[2].String())
+}
+
+func TestEvaluate_error(t *testing.T) {
+	p, err := New(Rule{Name: "bad", Select: "containers.*", Assert: "image > 2"})
+	require.NoError(t, err)
+	_, err = p.Evaluate(exampleWorkload())
+	assert.EqualError(t, err, "rule 'bad': containers.api: assert: cannot compare registry.example.com/api:1.2 > 2")
+
+	_, err = (&Policy{Rules: []Rule{{Name: "raw", Assert: "true"}}}).Evaluate(exampleWorkload())
+	assert.EqualError(t, err, "rule 'raw': is not compiled, use New, Parse, or Load to construct policies")
+}
+
+func TestParse_errors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		raw     string
+		wantErr string
+	}{
+		{name: "unknown field", raw: "rules: [{name: a, assert: 'true', unknown: x}]", wantErr: "decoding policy: yaml: unmarshal errors:\n  line 1: field unknown not found in type policy.Rule"},
+		{name: "missing name", raw: "rules: [{assert: 'true'}]", wantErr: "rules.0: name: is required"},
+		{name: "missing assert", raw: "rules: [{name: a}]", wantErr: "rules.0: rule 'a': assert: is required"},
+		{name: "bad severity", raw: "rules: [{name: a, severity: fatal, assert: 'true'}]", wantErr: "rules.0: rule 'a': severity: must be one of error, warning, or info"},
+		{name: "bad when", raw: "rules: [{name: a, when: '(', assert: 'true'}]", wantErr: "rules.0: rule 'a': when: unexpected end of expression at offset 1"},
+		{name: "duplicate", raw: "rules: [{name: a, assert: 'true'}, {name: a, assert: 'true'}]", wantErr: "rules.1: rule 'a' is defined more than once"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.raw))
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	td := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(td, "a.yaml"), []byte(examplePolicy), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(td, "b.yaml"), []byte("rules: [{name: has-containers, assert: 'len(containers) > 0'}]"), 0644))
+	p, err := Load(context.Background(), td, uriget.WithLogger(log.New(os.Stderr, "", 0)))
+	require.NoError(t, err)
+	assert.Len(t, p.Rules, 4)
+
+	require.NoError(t, os.WriteFile(filepath.Join(td, "c.yaml"), []byte("rules: [{name: has-containers, assert: 'true'}]"), 0644))
+	_, err = Load(context.Background(), td, uriget.WithLogger(log.New(os.Stderr, "", 0)))
+	assert.EqualError(t, err, "rules.4: rule 'has-containers' is defined more than once")
+}
+
+func TestValidationRule(t *testing.T) {
+	p, err := Parse([]byte(examplePolicy))
+	require.NoError(t, err)
+	err = loader.ValidateWithRules(exampleWorkload(), p.ValidationRule(SeverityWarning))
+	assert.EqualError(t, err, `validating workload:
+    error: containers.api.volumes./mnt/shared: volumes from shared resources must be mounted readOnly (no-writable-shared-volumes)
+    warning: containers.sidecar: assertion failed: startsWith(image, "registry.example.com/") (trusted-registry)`)
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// ApiVersionV1b1 is the apiVersion of Score files validated by ScoreSchemaV1b1.
+const ApiVersionV1b1 = "score.dev/v1b1"
+
+// DefaultApiVersion is the apiVersion whose schema is used when a document has no apiVersion string, so that the schema
+// can report the problem.
+const DefaultApiVersion = ApiVersionV1b1
+
+// ErrUnsupportedApiVersion is returned by Validate when the document's apiVersion has no registered schema.
+var ErrUnsupportedApiVersion = errors.New("unsupported apiVersion")
+
+type namedSchema struct {
+	name   string
+	source string
+}
+
+type versionedSchema struct {
+	source     string
+	extensions []namedSchema
+	// compiled caches the compiled main and extension schemas, it is reset when the extensions change.
+	compiled []*jsonschema.Schema
+}
+
+var (
+	schemasLock sync.RWMutex
+	schemas     = map[string]*versionedSchema{
+		ApiVersionV1b1: {source: ScoreSchemaV1b1},
+	}
+)
+
+// RegisterSchema adds a json schema for Score files with the given apiVersion. This allows implementations to trial
+// draft versions of the specification. An error is returned if the schema does not compile or a schema is already
+// registered for the apiVersion.
+func RegisterSchema(apiVersion string, source string) error {
+	if apiVersion == "" {
+		return fmt.Errorf("apiVersion must not be empty")
+	}
+	if _, err := jsonschema.CompileString("", source); err != nil {
+		return fmt.Errorf("compiling schema for '%s': %w", apiVersion, err)
+	}
+	schemasLock.Lock()
+	defer schemasLock.Unlock()
+	if _, ok := schemas[apiVersion]; ok {
+		return fmt.Errorf("schema for '%s' is already registered", apiVersion)
+	}
+	schemas[apiVersion] = &versionedSchema{source: source}
+	return nil
+}
+
+// UnregisterSchema removes a schema added with RegisterSchema along with its extensions. It returns false if no schema
+// was registered for the apiVersion. The built-in schemas cannot be removed.
+func UnregisterSchema(apiVersion string) bool {
+	if apiVersion == ApiVersionV1b1 {
+		return false
+	}
+	schemasLock.Lock()
+	defer schemasLock.Unlock()
+	if _, ok := schemas[apiVersion]; !ok {
+		return false
+	}
+	delete(schemas, apiVersion)
+	return true
+}
+
+// RegisterExtensionSchema adds a named json schema that documents with the given apiVersion must satisfy in addition
+// to the main schema. This allows implementations to restrict or document implementation specific fields such as
+// metadata annotations. Extensions are applied in the order they were registered.
+func RegisterExtensionSchema(apiVersion string, name string, source string) error {
+	if name == "" {
+		return fmt.Errorf("extension schema name must not be empty")
+	}
+	if _, err := jsonschema.CompileString("", source); err != nil {
+		return fmt.Errorf("compiling extension schema '%s': %w", name, err)
+	}
+	schemasLock.Lock()
+	defer schemasLock.Unlock()
+	s, ok := schemas[apiVersion]
+	if !ok {
+		return fmt.Errorf("%w '%s'", ErrUnsupportedApiVersion, apiVersion)
+	}
+	for _, e := range s.extensions {
+		if e.name == name {
+			return fmt.Errorf("extension schema '%s' is already registered for '%s'", name, apiVersion)
+		}
+	}
+	s.extensions = append(s.extensions, namedSchema{name: name, source: source})
+	s.compiled = nil
+	return nil
+}
+
+// UnregisterExtensionSchema removes an extension schema added with RegisterExtensionSchema. It returns false if no
+// extension with the given name was registered for the apiVersion.
+func UnregisterExtensionSchema(apiVersion string, name string) bool {
+	schemasLock.Lock()
+	defer schemasLock.Unlock()
+	s, ok := schemas[apiVersion]
+	if !ok {
+		return false
+	}
+	for i, e := range s.extensions {
+		if e.name == name {
+			s.extensions = append(s.extensions[:i:i], s.extensions[i+1:]...)
+			s.compiled = nil
+			return true
+		}
+	}
+	return false
+}
+
+// ApiVersions returns the sorted list of apiVersions that have a registered schema.
+func ApiVersions() []string {
+	schemasLock.RLock()
+	defer schemasLock.RUnlock()
+	out := make([]string, 0, len(schemas))
+	for v := range schemas {
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// SchemaFor returns the json schema registered for the apiVersion.
+func SchemaFor(apiVersion string) (string, bool) {
+	schemasLock.RLock()
+	defer schemasLock.RUnlock()
+	s, ok := schemas[apiVersion]
+	if !ok {
+		return "", false
+	}
+	return s.source, true
+}
+
+// compiledSchemasFor returns the compiled main schema followed by any extension schemas for the apiVersion. Schemas
+// are compiled on first use and cached.
+func compiledSchemasFor(apiVersion string) ([]*jsonschema.Schema, error) {
+	schemasLock.RLock()
+	s, ok := schemas[apiVersion]
+	var compiled []*jsonschema.Schema
+	if ok {
+		compiled = s.compiled
+	}
+	schemasLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w '%s', expected one of: %s", ErrUnsupportedApiVersion, apiVersion, strings.Join(ApiVersions(), ", "))
+	} else if compiled != nil {
+		return compiled, nil
+	}
+
+	schemasLock.Lock()
+	defer schemasLock.Unlock()
+	if s.compiled != nil {
+		return s.compiled, nil
+	}
+	compiled = make([]*jsonschema.Schema, 0, 1+len(s.extensions))
+	main, err := jsonschema.CompileString("", s.source)
+	if err != nil {
+		return nil, fmt.Errorf("compiling Score schema: %w", err)
+	}
+	compiled = append(compiled, main)
+	for _, e := range s.extensions {
+		extension, err := jsonschema.CompileString("", e.source)
+		if err != nil {
+			return nil, fmt.Errorf("compiling extension schema '%s': %w", e.name, err)
+		}
+		compiled = append(compiled, extension)
+	}
+	s.compiled = compiled
+	return compiled, nil
+}
+
+// documentApiVersion returns the apiVersion of the document, or DefaultApiVersion if it has no apiVersion string so
+// that the schema can report the problem.
+func documentApiVersion(src map[string]interface{}) string {
+	if apiVersion, ok := src["apiVersion"].(string); ok {
+		return apiVersion
+	}
+	return DefaultApiVersion
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const draftSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["apiVersion", "metadata", "workloads"],
+  "properties": {
+    "apiVersion": {"const": "score.dev/v2-draft"},
+    "metadata": {"type": "object"},
+    "workloads": {"type": "object"}
+  }
+}`
+
+func TestValidate_unsupportedApiVersion(t *testing.T) {
+	src := newTestDocument()
+	src["apiVersion"] = "score.dev/v2"
+	err := Validate(src)
+	assert.ErrorIs(t, err, ErrUnsupportedApiVersion)
+	assert.EqualError(t, err, "unsupported apiVersion 'score.dev/v2', expected one of: score.dev/v1b1")
+}
+
+func TestRegisterSchema(t *testing.T) {
+	require.NoError(t, RegisterSchema("score.dev/v2-draft", draftSchema))
+	defer UnregisterSchema("score.dev/v2-draft")
+
+	assert.Equal(t, []string{"score.dev/v1b1", "score.dev/v2-draft"}, ApiVersions())
+	source, ok := SchemaFor("score.dev/v2-draft")
+	assert.True(t, ok)
+	assert.Equal(t, draftSchema, source)
+	_, ok = SchemaFor("score.dev/v3")
+	assert.False(t, ok)
+
+	assert.NoError(t, Validate(map[string]interface{}{
+		"apiVersion": "score.dev/v2-draft",
+		"metadata":   map[string]interface{}{},
+		"workloads":  map[string]interface{}{},
+	}))
+	err := Validate(map[string]interface{}{"apiVersion": "score.dev/v2-draft", "metadata": map[string]interface{}{}})
+	assert.IsType(t, &jsonschema.ValidationError{}, err)
+	assert.ErrorContains(t, err, "missing properties: 'workloads'")
+
+	// the v1b1 schema is still used for v1b1 documents
+	assert.NoError(t, Validate(newTestDocument()))
+
+	assert.EqualError(t, RegisterSchema("score.dev/v2-draft", draftSchema), "schema for 'score.dev/v2-draft' is already registered")
+	assert.EqualError(t, RegisterSchema("", draftSchema), "apiVersion must not be empty")
+	assert.ErrorContains(t, RegisterSchema("score.dev/v3", "{"), "compiling schema for 'score.dev/v3'")
+
+	assert.True(t, UnregisterSchema("score.dev/v2-draft"))
+	assert.False(t, UnregisterSchema("score.dev/v2-draft"))
+	assert.False(t, UnregisterSchema(ApiVersionV1b1), "built-in schemas cannot be removed")
+	assert.Equal(t, []string{"score.dev/v1b1"}, ApiVersions())
+}
+
+func TestRegisterExtensionSchema(t *testing.T) {
+	extension := `{
+  "type": "object",
+  "properties": {
+    "metadata": {
+      "type": "object",
+      "required": ["annotations"]
+    }
+  }
+}`
+	require.NoError(t, RegisterExtensionSchema(ApiVersionV1b1, "require-annotations", extension))
+	defer UnregisterExtensionSchema(ApiVersionV1b1, "require-annotations")
+
+	src := newTestDocument()
+	err := Validate(src)
+	assert.IsType(t, &jsonschema.ValidationError{}, err)
+	assert.ErrorContains(t, err, "missing properties: 'annotations'")
+
+	src["metadata"].(map[string]interface{})["annotations"] = map[string]interface{}{"acme.org/team": "b"}
+	assert.NoError(t, Validate(src))
+
+	assert.EqualError(t, RegisterExtensionSchema(ApiVersionV1b1, "require-annotations", extension), "extension schema 'require-annotations' is already registered for 'score.dev/v1b1'")
+	assert.EqualError(t, RegisterExtensionSchema("score.dev/v3", "x", extension), "unsupported apiVersion 'score.dev/v3'")
+	assert.EqualError(t, RegisterExtensionSchema(ApiVersionV1b1, "", extension), "extension schema name must not be empty")
+	assert.ErrorContains(t, RegisterExtensionSchema(ApiVersionV1b1, "broken", "{"), "compiling extension schema 'broken'")
+
+	assert.True(t, UnregisterExtensionSchema(ApiVersionV1b1, "require-annotations"))
+	assert.False(t, UnregisterExtensionSchema(ApiVersionV1b1, "require-annotations"))
+	assert.False(t, UnregisterExtensionSchema("score.dev/v3", "require-annotations"))
+	assert.NoError(t, Validate(newTestDocument()))
+}
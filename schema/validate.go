@@ -15,13 +15,11 @@
 package schema
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
 
-	"github.com/santhosh-tekuri/jsonschema/v5"
 	"gopkg.in/yaml.v3"
 
 	"github.com/score-spec/score-go/types"
@@ -56,25 +54,28 @@ func ValidateYaml(r io.Reader) error {
 	return Validate(obj)
 }
 
-// ValidateSpec validates a workload spec structure by serializing it to yaml and calling ValidateYaml.
+// ValidateSpec validates a workload spec structure. The workload is converted directly into the structure expected by
+// the schema of its apiVersion without serializing it.
 func ValidateSpec(spec *types.Workload) error {
-	intermediate, err := yaml.Marshal(spec)
+	obj, err := specToValue(spec)
 	if err != nil {
-		return fmt.Errorf("failed to marshal to yaml: %w", err)
+		return err
 	}
-	return ValidateYaml(bytes.NewReader(intermediate))
+	return Validate(obj)
 }
 
 // Validate validates the source structure which should be a decoded map. Generally, you must call
 // ApplyCommonUpgradeTransforms on the raw structure first unless the input contains zero deprecated concepts.
-// For all validation errors returned error would be a *jsonschema.ValidationError.
+// The schema is chosen by the apiVersion of the source, see RegisterSchema, and any extension schemas registered for
+// that apiVersion are applied after it. An error wrapping ErrUnsupportedApiVersion is returned if no schema is
+// registered for the apiVersion. Schemas are compiled once and cached, use NewValidator to hold on to the compiled
+// schemas of a single apiVersion. For all validation errors returned error would be a *jsonschema.ValidationError.
 func Validate(src map[string]interface{}) error {
-	schema, err := jsonschema.CompileString("", ScoreSchemaV1b1)
+	compiled, err := compiledSchemasFor(documentApiVersion(src))
 	if err != nil {
-		return fmt.Errorf("compiling Score schema: %w", err)
+		return err
 	}
-
-	return schema.Validate(src)
+	return (&Validator{schemas: compiled}).Validate(src)
 }
 
 // ApplyCommonUpgradeTransforms when we fix aspects of the score spec over time, we sometimes need to break compatibility.
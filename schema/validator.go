@@ -0,0 +1,198 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/score-spec/score-go/types"
+)
+
+// Validator validates documents against the compiled schemas of a single apiVersion. The schemas are compiled once
+// when the Validator is created, so a Validator should be reused when validating many documents. Extension schemas
+// registered after the Validator was created are not applied. A Validator is safe for concurrent use.
+type Validator struct {
+	apiVersion string
+	schemas    []*jsonschema.Schema
+}
+
+// NewValidator returns a Validator for the given apiVersion. An error wrapping ErrUnsupportedApiVersion is returned
+// if no schema is registered for the apiVersion.
+func NewValidator(apiVersion string) (*Validator, error) {
+	compiled, err := compiledSchemasFor(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{apiVersion: apiVersion, schemas: compiled}, nil
+}
+
+// ApiVersion returns the apiVersion the validator was created for.
+func (v *Validator) ApiVersion() string {
+	return v.apiVersion
+}
+
+// Validate validates the source structure which should be a decoded map. For all validation errors returned error
+// would be a *jsonschema.ValidationError.
+func (v *Validator) Validate(src map[string]interface{}) error {
+	for _, s := range v.schemas {
+		if err := s.Validate(src); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateSpec validates a workload spec structure. The workload is converted directly into the structure expected by
+// the schema without serializing it.
+func (v *Validator) ValidateSpec(spec *types.Workload) error {
+	obj, err := specToValue(spec)
+	if err != nil {
+		return err
+	}
+	return v.Validate(obj)
+}
+
+// specToValue converts the workload into the generic structure that json decoding would produce.
+func specToValue(spec *types.Workload) (map[string]interface{}, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("workload is nil")
+	}
+	out, err := toSchemaValue(reflect.ValueOf(spec).Elem())
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert workload: %w", err)
+	}
+	return out.(map[string]interface{}), nil
+}
+
+// toSchemaValue converts a Go value into one of the types understood by the jsonschema library. Struct fields are
+// named and omitted according to their json tags. Nil maps and slices become empty values like the yaml encoder
+// produces.
+func toSchemaValue(v reflect.Value) (interface{}, error) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil, nil
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return toSchemaValue(v.Elem())
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Slice, reflect.Array:
+		out := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item, err := toSchemaValue(v.Index(i))
+			if err != nil {
+				return nil, fmt.Errorf("%d: %w", i, err)
+			}
+			out[i] = item
+		}
+		return out, nil
+	case reflect.Map:
+		out := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := iter.Key()
+			for key.Kind() == reflect.Interface && !key.IsNil() {
+				key = key.Elem()
+			}
+			var k string
+			if key.Kind() == reflect.String {
+				k = key.String()
+			} else {
+				k = fmt.Sprint(key.Interface())
+			}
+			item, err := toSchemaValue(iter.Value())
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			out[k] = item
+		}
+		return out, nil
+	case reflect.Struct:
+		if _, ok := v.Interface().(json.Marshaler); ok {
+			return jsonRoundTrip(v)
+		}
+		out := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			} else if name == "" {
+				name = field.Name
+			}
+			if strings.Contains(options, "omitempty") && isEmptyValue(v.Field(i)) {
+				continue
+			}
+			item, err := toSchemaValue(v.Field(i))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			out[name] = item
+		}
+		return out, nil
+	default:
+		return jsonRoundTrip(v)
+	}
+}
+
+// jsonRoundTrip converts values with custom json encoding or unusual types such as complex numbers.
+func jsonRoundTrip(v reflect.Value) (interface{}, error) {
+	raw, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// isEmptyValue matches the omitempty behavior of encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
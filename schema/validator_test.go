@@ -0,0 +1,149 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/score-spec/score-go/types"
+)
+
+func TestNewValidator(t *testing.T) {
+	v, err := NewValidator(ApiVersionV1b1)
+	require.NoError(t, err)
+	assert.Equal(t, ApiVersionV1b1, v.ApiVersion())
+	assert.NoError(t, v.Validate(newTestDocument()))
+
+	src := newTestDocument()
+	delete(src, "containers")
+	err = v.Validate(src)
+	assert.IsType(t, &jsonschema.ValidationError{}, err)
+	assert.ErrorContains(t, err, "missing properties: 'containers'")
+
+	_, err = NewValidator("score.dev/v2")
+	assert.ErrorIs(t, err, ErrUnsupportedApiVersion)
+}
+
+func TestValidator_concurrent(t *testing.T) {
+	v, err := NewValidator(ApiVersionV1b1)
+	require.NoError(t, err)
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, v.Validate(newTestDocument()))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCompiledSchemasAreCached(t *testing.T) {
+	first, err := compiledSchemasFor(ApiVersionV1b1)
+	require.NoError(t, err)
+	second, err := compiledSchemasFor(ApiVersionV1b1)
+	require.NoError(t, err)
+	assert.Same(t, first[0], second[0])
+
+	require.NoError(t, RegisterExtensionSchema(ApiVersionV1b1, "noop", `{"type": "object"}`))
+	defer UnregisterExtensionSchema(ApiVersionV1b1, "noop")
+	third, err := compiledSchemasFor(ApiVersionV1b1)
+	require.NoError(t, err)
+	assert.Len(t, third, 2, "registering an extension must reset the cache")
+}
+
+func TestValidateSpec_matchesYamlRoundTrip(t *testing.T) {
+	for name, raw := range map[string]string{
+		"full": `
+apiVersion: score.dev/v1b1
+metadata:
+  name: hello-world
+  annotations:
+    acme.org/count: 3
+service:
+  ports:
+    www:
+      port: 80
+      targetPort: 8080
+containers:
+  hello:
+    image: busybox
+    command: ["/bin/echo"]
+    files:
+      /etc/config:
+        content: hello
+        noExpand: true
+    volumes:
+      /mnt/data:
+        source: ${resources.data}
+        readOnly: false
+    livenessProbe:
+      exec:
+        command: ["true"]
+resources:
+  data:
+    type: volume
+    params:
+      nested:
+        list: [1, "two", 3.5, null]
+`,
+		"invalid port": `
+apiVersion: score.dev/v1b1
+metadata:
+  name: hello-world
+service:
+  ports:
+    www:
+      port: 0
+containers:
+  hello:
+    image: busybox
+`,
+		"empty exec command": `
+apiVersion: score.dev/v1b1
+metadata:
+  name: hello-world
+containers:
+  hello:
+    image: busybox
+    livenessProbe:
+      exec: {}
+`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var spec types.Workload
+			require.NoError(t, yaml.Unmarshal([]byte(raw), &spec))
+
+			intermediate, err := yaml.Marshal(&spec)
+			require.NoError(t, err)
+			viaYaml := ValidateYaml(bytes.NewReader(intermediate))
+			direct := ValidateSpec(&spec)
+			if viaYaml == nil {
+				assert.NoError(t, direct)
+			} else {
+				assert.EqualError(t, direct, viaYaml.Error())
+			}
+		})
+	}
+
+	assert.EqualError(t, ValidateSpec(nil), "workload is nil")
+}
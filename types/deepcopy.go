@@ -0,0 +1,346 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// This file contains DeepCopy methods for the types in types.gen.go. When the generated types change, these must be
+// updated to match. TestDeepCopy_coversAllTypes will fail if a struct or map type has no DeepCopy method, and
+// TestDeepCopy_coversAllFields will fail if a field is not copied.
+
+// deepCopyValue copies the decoded json or yaml structures that may be found in metadata and params.
+func deepCopyValue(in interface{}) interface{} {
+	switch typed := in.(type) {
+	case map[string]interface{}:
+		return deepCopyMap(typed)
+	case []interface{}:
+		if typed == nil {
+			return typed
+		}
+		out := make([]interface{}, len(typed))
+		for i, v := range typed {
+			out[i] = deepCopyValue(v)
+		}
+		return out
+	case map[interface{}]interface{}:
+		if typed == nil {
+			return typed
+		}
+		out := make(map[interface{}]interface{}, len(typed))
+		for k, v := range typed {
+			out[k] = deepCopyValue(v)
+		}
+		return out
+	default:
+		return in
+	}
+}
+
+func deepCopyMap(in map[string]interface{}) map[string]interface{} {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(in))
+	for k, v := range in {
+		out[k] = deepCopyValue(v)
+	}
+	return out
+}
+
+func deepCopyPtr[k any](in *k) *k {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	return &out
+}
+
+func deepCopySlice[k any](in []k) []k {
+	if in == nil {
+		return nil
+	}
+	out := make([]k, len(in))
+	copy(out, in)
+	return out
+}
+
+// DeepCopy returns a deep copy of the workload.
+func (in *Workload) DeepCopy() *Workload {
+	if in == nil {
+		return nil
+	}
+	out := new(Workload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the workload into out. No memory is shared between in and out.
+func (in *Workload) DeepCopyInto(out *Workload) {
+	*out = *in
+	out.Containers = in.Containers.DeepCopy()
+	out.Metadata = in.Metadata.DeepCopy()
+	out.Resources = in.Resources.DeepCopy()
+	out.Service = in.Service.DeepCopy()
+}
+
+// DeepCopy returns a deep copy of the workload metadata.
+func (in WorkloadMetadata) DeepCopy() WorkloadMetadata {
+	return deepCopyMap(in)
+}
+
+// DeepCopy returns a deep copy of the workload containers.
+func (in WorkloadContainers) DeepCopy() WorkloadContainers {
+	if in == nil {
+		return nil
+	}
+	out := make(WorkloadContainers, len(in))
+	for k, v := range in {
+		out[k] = *v.DeepCopy()
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of the workload resources.
+func (in WorkloadResources) DeepCopy() WorkloadResources {
+	if in == nil {
+		return nil
+	}
+	out := make(WorkloadResources, len(in))
+	for k, v := range in {
+		out[k] = *v.DeepCopy()
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of the workload service.
+func (in *WorkloadService) DeepCopy() *WorkloadService {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadService)
+	out.Ports = in.Ports.DeepCopy()
+	return out
+}
+
+// DeepCopy returns a deep copy of the workload service ports.
+func (in WorkloadServicePorts) DeepCopy() WorkloadServicePorts {
+	if in == nil {
+		return nil
+	}
+	out := make(WorkloadServicePorts, len(in))
+	for k, v := range in {
+		out[k] = *v.DeepCopy()
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of the service port.
+func (in *ServicePort) DeepCopy() *ServicePort {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Protocol = deepCopyPtr(in.Protocol)
+	out.TargetPort = deepCopyPtr(in.TargetPort)
+	return &out
+}
+
+// DeepCopy returns a deep copy of the container.
+func (in *Container) DeepCopy() *Container {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Args = deepCopySlice(in.Args)
+	out.Before = in.Before.DeepCopy()
+	out.Command = deepCopySlice(in.Command)
+	out.Files = in.Files.DeepCopy()
+	out.LivenessProbe = in.LivenessProbe.DeepCopy()
+	out.ReadinessProbe = in.ReadinessProbe.DeepCopy()
+	out.Resources = in.Resources.DeepCopy()
+	out.Variables = in.Variables.DeepCopy()
+	out.Volumes = in.Volumes.DeepCopy()
+	return &out
+}
+
+// DeepCopy returns a deep copy of the container before entries.
+func (in ContainerBefore) DeepCopy() ContainerBefore {
+	if in == nil {
+		return nil
+	}
+	out := make(ContainerBefore, len(in))
+	for k, v := range in {
+		out[k] = *v.DeepCopy()
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of the container before entry.
+func (in *ContainerBeforeEntry) DeepCopy() *ContainerBeforeEntry {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	return &out
+}
+
+// DeepCopy returns a deep copy of the container files.
+func (in ContainerFiles) DeepCopy() ContainerFiles {
+	if in == nil {
+		return nil
+	}
+	out := make(ContainerFiles, len(in))
+	for k, v := range in {
+		out[k] = *v.DeepCopy()
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of the container file.
+func (in *ContainerFile) DeepCopy() *ContainerFile {
+	if in == nil {
+		return nil
+	}
+	return &ContainerFile{
+		BinaryContent: deepCopyPtr(in.BinaryContent),
+		Content:       deepCopyPtr(in.Content),
+		Mode:          deepCopyPtr(in.Mode),
+		NoExpand:      deepCopyPtr(in.NoExpand),
+		Source:        deepCopyPtr(in.Source),
+	}
+}
+
+// DeepCopy returns a deep copy of the container probe.
+func (in *ContainerProbe) DeepCopy() *ContainerProbe {
+	if in == nil {
+		return nil
+	}
+	return &ContainerProbe{
+		Exec:    in.Exec.DeepCopy(),
+		HttpGet: in.HttpGet.DeepCopy(),
+	}
+}
+
+// DeepCopy returns a deep copy of the exec probe.
+func (in *ExecProbe) DeepCopy() *ExecProbe {
+	if in == nil {
+		return nil
+	}
+	return &ExecProbe{Command: deepCopySlice(in.Command)}
+}
+
+// DeepCopy returns a deep copy of the http probe.
+func (in *HttpProbe) DeepCopy() *HttpProbe {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Host = deepCopyPtr(in.Host)
+	if in.HttpHeaders != nil {
+		out.HttpHeaders = make([]HttpProbeHttpHeadersElem, len(in.HttpHeaders))
+		for i, h := range in.HttpHeaders {
+			out.HttpHeaders[i] = *h.DeepCopy()
+		}
+	}
+	out.Scheme = deepCopyPtr(in.Scheme)
+	return &out
+}
+
+// DeepCopy returns a deep copy of the http probe header.
+func (in *HttpProbeHttpHeadersElem) DeepCopy() *HttpProbeHttpHeadersElem {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	return &out
+}
+
+// DeepCopy returns a deep copy of the container resources.
+func (in *ContainerResources) DeepCopy() *ContainerResources {
+	if in == nil {
+		return nil
+	}
+	return &ContainerResources{
+		Limits:   in.Limits.DeepCopy(),
+		Requests: in.Requests.DeepCopy(),
+	}
+}
+
+// DeepCopy returns a deep copy of the resource limits.
+func (in *ResourcesLimits) DeepCopy() *ResourcesLimits {
+	if in == nil {
+		return nil
+	}
+	return &ResourcesLimits{
+		Cpu:    deepCopyPtr(in.Cpu),
+		Memory: deepCopyPtr(in.Memory),
+	}
+}
+
+// DeepCopy returns a deep copy of the container variables.
+func (in ContainerVariables) DeepCopy() ContainerVariables {
+	if in == nil {
+		return nil
+	}
+	out := make(ContainerVariables, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of the container volumes.
+func (in ContainerVolumes) DeepCopy() ContainerVolumes {
+	if in == nil {
+		return nil
+	}
+	out := make(ContainerVolumes, len(in))
+	for k, v := range in {
+		out[k] = *v.DeepCopy()
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of the container volume.
+func (in *ContainerVolume) DeepCopy() *ContainerVolume {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Path = deepCopyPtr(in.Path)
+	out.ReadOnly = deepCopyPtr(in.ReadOnly)
+	return &out
+}
+
+// DeepCopy returns a deep copy of the resource.
+func (in *Resource) DeepCopy() *Resource {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Class = deepCopyPtr(in.Class)
+	out.Id = deepCopyPtr(in.Id)
+	out.Metadata = in.Metadata.DeepCopy()
+	out.Params = in.Params.DeepCopy()
+	return &out
+}
+
+// DeepCopy returns a deep copy of the resource metadata.
+func (in ResourceMetadata) DeepCopy() ResourceMetadata {
+	return deepCopyMap(in)
+}
+
+// DeepCopy returns a deep copy of the resource params.
+func (in ResourceParams) DeepCopy() ResourceParams {
+	return deepCopyMap(in)
+}
@@ -0,0 +1,268 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// fillValue sets every field, map, slice, and pointer reachable from v to a non-zero value.
+func fillValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		fillValue(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			fillValue(v.Field(i))
+		}
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+		key := reflect.New(v.Type().Key()).Elem()
+		fillValue(key)
+		elem := reflect.New(v.Type().Elem()).Elem()
+		fillValue(elem)
+		v.SetMapIndex(key, elem)
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fillValue(v.Index(0))
+	case reflect.Interface:
+		v.Set(reflect.ValueOf(map[string]interface{}{"nested": []interface{}{"value"}}))
+	case reflect.String:
+		v.SetString("value")
+	case reflect.Int:
+		v.SetInt(1)
+	case reflect.Bool:
+		v.SetBool(true)
+	}
+}
+
+// assertNoSharedMemory fails if any map, slice, or pointer reachable from a is also reachable from b.
+func assertNoSharedMemory(t *testing.T, path string, a, b reflect.Value) {
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if !a.IsNil() && a.Pointer() == b.Pointer() {
+			t.Errorf("%s is shared between the original and the copy", path)
+			return
+		}
+	}
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !a.IsNil() {
+			assertNoSharedMemory(t, path, a.Elem(), b.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			assertNoSharedMemory(t, path+"."+a.Type().Field(i).Name, a.Field(i), b.Field(i))
+		}
+	case reflect.Map:
+		iter := a.MapRange()
+		for iter.Next() {
+			assertNoSharedMemory(t, path+"["+iter.Key().String()+"]", iter.Value(), b.MapIndex(iter.Key()))
+		}
+	case reflect.Slice:
+		for i := 0; i < a.Len(); i++ {
+			assertNoSharedMemory(t, path+"[]", a.Index(i), b.Index(i))
+		}
+	}
+}
+
+func TestDeepCopy_coversAllFields(t *testing.T) {
+	var w Workload
+	fillValue(reflect.ValueOf(&w).Elem())
+	out := w.DeepCopy()
+	assert.Equal(t, &w, out)
+	assertNoSharedMemory(t, "workload", reflect.ValueOf(w), reflect.ValueOf(*out))
+
+	var c Container
+	fillValue(reflect.ValueOf(&c).Elem())
+	assert.Equal(t, &c, c.DeepCopy())
+	assertNoSharedMemory(t, "container", reflect.ValueOf(c), reflect.ValueOf(*c.DeepCopy()))
+
+	var r Resource
+	fillValue(reflect.ValueOf(&r).Elem())
+	assert.Equal(t, &r, r.DeepCopy())
+	assertNoSharedMemory(t, "resource", reflect.ValueOf(r), reflect.ValueOf(*r.DeepCopy()))
+}
+
+func TestDeepCopy_coversAllTypes(t *testing.T) {
+	fset := token.NewFileSet()
+	methods := make(map[string]bool)
+	for _, name := range []string{"types.gen.go", "deepcopy.go"} {
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		require.NoError(t, err)
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Name.Name == "DeepCopy" && fn.Recv != nil {
+				recv := fn.Recv.List[0].Type
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+				methods[recv.(*ast.Ident).Name] = true
+			}
+		}
+	}
+
+	f, err := parser.ParseFile(fset, "types.gen.go", nil, 0)
+	require.NoError(t, err)
+	var checked int
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			switch ts.Type.(type) {
+			case *ast.StructType, *ast.MapType, *ast.ArrayType:
+				checked++
+				assert.True(t, methods[ts.Name.Name], "generated type %s has no DeepCopy method", ts.Name.Name)
+			}
+		}
+	}
+	assert.NotZero(t, checked)
+}
+
+func TestDeepCopy_nil(t *testing.T) {
+	var w *Workload
+	assert.Nil(t, w.DeepCopy())
+	out := (&Workload{ApiVersion: "score.dev/v1b1"}).DeepCopy()
+	assert.Equal(t, &Workload{ApiVersion: "score.dev/v1b1"}, out)
+	assert.Nil(t, out.Containers)
+	assert.Nil(t, out.Metadata)
+}
+
+func TestDeepCopy_mutationIsolated(t *testing.T) {
+	w := &Workload{
+		ApiVersion: "score.dev/v1b1",
+		Metadata:   WorkloadMetadata{"name": "example", "annotations": map[string]interface{}{"a": "b"}},
+		Containers: WorkloadContainers{"main": {Image: "nginx", Variables: ContainerVariables{"A": "B"}}},
+		Resources: WorkloadResources{"db": {Type: "postgres", Params: ResourceParams{
+			"list": []interface{}{map[string]interface{}{"x": 1}},
+		}}},
+	}
+	out := w.DeepCopy()
+	out.Metadata["annotations"].(map[string]interface{})["a"] = "c"
+	out.Containers["main"].Variables["A"] = "C"
+	out.Resources["db"].Params["list"].([]interface{})[0].(map[string]interface{})["x"] = 2
+
+	assert.Equal(t, "b", w.Metadata["annotations"].(map[string]interface{})["a"])
+	assert.Equal(t, "B", w.Containers["main"].Variables["A"])
+	assert.Equal(t, 1, w.Resources["db"].Params["list"].([]interface{})[0].(map[string]interface{})["x"])
+}
+
+func TestWorkload_Equal(t *testing.T) {
+	base := func() *Workload {
+		return &Workload{
+			ApiVersion: "score.dev/v1b1",
+			Metadata:   WorkloadMetadata{"name": "example"},
+			Containers: WorkloadContainers{"main": {Image: "nginx", Args: []string{"a"}}},
+			Resources:  WorkloadResources{"db": {Type: "postgres", Params: ResourceParams{"size": 1}}},
+		}
+	}
+
+	assert.True(t, base().Equal(base()))
+	assert.True(t, (*Workload)(nil).Equal(nil))
+	assert.False(t, base().Equal(nil))
+
+	t.Run("nil and empty maps", func(t *testing.T) {
+		a, b := base(), base()
+		a.Containers["main"] = Container{Image: "nginx", Args: []string{"a"}, Variables: ContainerVariables{}}
+		b.Service = nil
+		assert.True(t, a.Equal(b))
+		assert.True(t, b.Equal(a))
+		assert.False(t, reflect.DeepEqual(a, b))
+	})
+
+	t.Run("numbers of different types", func(t *testing.T) {
+		a, b := base(), base()
+		b.Resources["db"] = Resource{Type: "postgres", Params: ResourceParams{"size": 1.0}}
+		assert.True(t, a.Equal(b))
+	})
+
+	t.Run("json and yaml decoded", func(t *testing.T) {
+		raw := []byte(`{"apiVersion":"score.dev/v1b1","metadata":{"name":"example","ports":[1,2]},"containers":{"main":{"image":"nginx"}}}`)
+		var a, b Workload
+		require.NoError(t, json.Unmarshal(raw, &a))
+		require.NoError(t, yaml.Unmarshal(raw, &b))
+		assert.True(t, a.Equal(&b))
+	})
+
+	t.Run("zoned timestamps", func(t *testing.T) {
+		decode := func(raw string) map[string]interface{} {
+			var out map[string]interface{}
+			require.NoError(t, yaml.Unmarshal([]byte(raw), &out))
+			return out
+		}
+		a, b := base(), base()
+		a.Metadata["created"] = decode("x: !!timestamp 2024-01-01T00:00:00+02:00")["x"]
+		b.Metadata["created"] = decode("x: !!timestamp 2024-01-01T00:00:00+02:00")["x"]
+		assert.True(t, SemanticEqual(decode("x: !!timestamp 2024-01-01T00:00:00+02:00"), decode("x: !!timestamp 2024-01-01T00:00:00+02:00")))
+		assert.True(t, a.Equal(b))
+
+		b.Metadata["created"] = decode("x: !!timestamp 2023-12-31T22:00:00Z")["x"]
+		assert.True(t, a.Equal(b), "the same instant in another zone is equal")
+
+		b.Metadata["created"] = decode("x: !!timestamp 2024-01-01T00:00:00Z")["x"]
+		assert.False(t, a.Equal(b))
+	})
+
+	t.Run("maps with different key types", func(t *testing.T) {
+		a, b := base(), base()
+		a.Metadata["labels"] = map[interface{}]interface{}{"app": "example", 1: "one"}
+		b.Metadata["labels"] = map[string]interface{}{"app": "example", "1": "one"}
+		assert.False(t, a.Equal(b))
+		assert.False(t, b.Equal(a))
+
+		a.Metadata["labels"] = map[interface{}]interface{}{"app": "example"}
+		b.Metadata["labels"] = map[string]interface{}{"app": "example"}
+		assert.True(t, a.Equal(b))
+		assert.True(t, b.Equal(a))
+
+		b.Metadata["labels"] = map[string]interface{}{"app": "other"}
+		assert.False(t, a.Equal(b))
+		assert.False(t, b.Equal(a))
+	})
+
+	t.Run("differences", func(t *testing.T) {
+		for _, mutate := range []func(w *Workload){
+			func(w *Workload) { w.ApiVersion = "other" },
+			func(w *Workload) { w.Metadata["name"] = "other" },
+			func(w *Workload) { w.Metadata["extra"] = "value" },
+			func(w *Workload) { w.Containers["main"] = Container{Image: "nginx"} },
+			func(w *Workload) { w.Resources["db"] = Resource{Type: "postgres", Params: ResourceParams{"size": "1"}} },
+			func(w *Workload) { w.Service = &WorkloadService{Ports: WorkloadServicePorts{"web": {Port: 80}}} },
+		} {
+			a, b := base(), base()
+			mutate(b)
+			assert.False(t, a.Equal(b))
+			assert.False(t, b.Equal(a))
+		}
+	})
+
+	t.Run("container and resource", func(t *testing.T) {
+		assert.True(t, (&Container{Image: "a", Files: ContainerFiles{}}).Equal(&Container{Image: "a"}))
+		assert.False(t, (&Container{Image: "a"}).Equal(&Container{Image: "b"}))
+		assert.True(t, (&Resource{Type: "a", Metadata: ResourceMetadata{}}).Equal(&Resource{Type: "a"}))
+	})
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"reflect"
+)
+
+// Equal returns true if the two workloads are semantically equal. Unlike reflect.DeepEqual, nil and empty maps or
+// slices are treated as equal, and numbers decoded from json or yaml are compared by value regardless of their Go type.
+func (in *Workload) Equal(other *Workload) bool {
+	return semanticEqual(reflect.ValueOf(in), reflect.ValueOf(other))
+}
+
+// Equal returns true if the two containers are semantically equal. See Workload.Equal.
+func (in *Container) Equal(other *Container) bool {
+	return semanticEqual(reflect.ValueOf(in), reflect.ValueOf(other))
+}
+
+// Equal returns true if the two resources are semantically equal. See Workload.Equal.
+func (in *Resource) Equal(other *Resource) bool {
+	return semanticEqual(reflect.ValueOf(in), reflect.ValueOf(other))
+}
+
+// SemanticEqual returns true if the two values are semantically equal using the same rules as Workload.Equal. It is
+// intended for comparing values found in metadata and params.
+func SemanticEqual(a, b interface{}) bool {
+	return semanticEqual(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func semanticEqual(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Interface {
+		a = a.Elem()
+	}
+	if b.Kind() == reflect.Interface {
+		b = b.Elem()
+	}
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if af, ok := numericValue(a); ok {
+		bf, ok := numericValue(b)
+		return ok && af == bf
+	}
+	if a.Type() != b.Type() {
+		// maps and slices decoded from json or yaml may have named or unnamed types
+		if a.Kind() != b.Kind() || (a.Kind() != reflect.Map && a.Kind() != reflect.Slice) {
+			return false
+		}
+	}
+	switch a.Kind() {
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return semanticEqual(a.Elem(), b.Elem())
+	case reflect.Struct:
+		if equal, ok := equalMethod(a, b); ok {
+			return equal
+		}
+		for i := 0; i < a.NumField(); i++ {
+			if !semanticEqual(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			bv := mapIndex(b, iter.Key())
+			if !bv.IsValid() || !semanticEqual(iter.Value(), bv) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !semanticEqual(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	default:
+		// values reached through unexported fields cannot be converted with Interface
+		return a.Comparable() && a.Equal(b)
+	}
+}
+
+// equalMethod compares the two structs with their Equal method if they have one with the signature
+// func (T) Equal(T) bool, such as time.Time.
+func equalMethod(a, b reflect.Value) (bool, bool) {
+	if !a.CanInterface() || !b.CanInterface() {
+		return false, false
+	}
+	m := a.MethodByName("Equal")
+	if !m.IsValid() {
+		return false, false
+	}
+	mt := m.Type()
+	if mt.NumIn() != 1 || mt.In(0) != a.Type() || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+	return m.Call([]reflect.Value{b})[0].Bool(), true
+}
+
+// mapIndex returns the value of the key in the map, or the zero Value if it is not present. Keys of maps with different
+// key types, such as map[string]interface{} and map[interface{}]interface{} decoded from yaml, are matched by their
+// underlying value.
+func mapIndex(m reflect.Value, key reflect.Value) reflect.Value {
+	if key.Kind() == reflect.Interface {
+		key = key.Elem()
+	}
+	if !key.IsValid() {
+		return reflect.Value{}
+	}
+	keyType := m.Type().Key()
+	if key.Type().AssignableTo(keyType) {
+		return m.MapIndex(key)
+	} else if key.Kind() == keyType.Kind() && key.Type().ConvertibleTo(keyType) {
+		return m.MapIndex(key.Convert(keyType))
+	}
+	return reflect.Value{}
+}
+
+// numericValue returns the value of a number with a builtin type as a float64.
+func numericValue(v reflect.Value) (float64, bool) {
+	if v.Type().PkgPath() != "" {
+		return 0, false
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// QuantityFormat describes the suffix family used when formatting a Quantity.
+type QuantityFormat string
+
+const (
+	// DecimalSI uses the decimal suffixes n, u, m, k, M, G, T, P, and E. For example 500m or 1500M.
+	DecimalSI QuantityFormat = "DecimalSI"
+	// BinarySI uses the binary suffixes Ki, Mi, Gi, Ti, Pi, and Ei. For example 1536Mi.
+	BinarySI QuantityFormat = "BinarySI"
+	// DecimalExponent uses a decimal exponent that is a multiple of 3. For example 1e9.
+	DecimalExponent QuantityFormat = "DecimalExponent"
+)
+
+// Quantity is a fixed-point representation of a cpu or memory amount that is compatible with Kubernetes resource
+// quantities. Quantities are immutable and the zero value represents 0. Quantities are precise to 1n (10^-9), and
+// any finer precision is rounded up when formatted.
+type Quantity struct {
+	value  *big.Rat
+	format QuantityFormat
+}
+
+var (
+	decimalSuffixes = map[string]int{"n": -9, "u": -6, "m": -3, "": 0, "k": 3, "K": 3, "M": 6, "G": 9, "T": 12, "P": 15, "E": 18}
+	binarySuffixes  = map[string]uint{"Ki": 10, "Mi": 20, "Gi": 30, "Ti": 40, "Pi": 50, "Ei": 60}
+	// decimalSuffixByExponent is the canonical suffix for each decimal exponent.
+	decimalSuffixByExponent = map[int]string{-9: "n", -6: "u", -3: "m", 0: "", 3: "k", 6: "M", 9: "G", 12: "T", 15: "P", 18: "E"}
+	binarySuffixByPower     = []string{"", "Ki", "Mi", "Gi", "Ti", "Pi", "Ei"}
+)
+
+// ParseQuantity parses a quantity string such as 250m, 2, 1.5Gi, 128M, or 1e9. The accepted syntax is a signed
+// decimal number followed by an optional decimal suffix (n, u, m, k or K, M, G, T, P, E), binary suffix (Ki, Mi, Gi,
+// Ti, Pi, Ei), or decimal exponent (e or E followed by an integer).
+func ParseQuantity(raw string) (Quantity, error) {
+	number, suffix := splitQuantity(raw)
+	if !strings.ContainsAny(number, "0123456789") || strings.Count(number, ".") > 1 {
+		return Quantity{}, fmt.Errorf("quantity '%s' is not a number with an optional unit", raw)
+	}
+	value, ok := new(big.Rat).SetString(strings.TrimPrefix(number, "+"))
+	if !ok {
+		return Quantity{}, fmt.Errorf("quantity '%s' is not a number with an optional unit", raw)
+	}
+	if exp, ok := decimalSuffixes[suffix]; ok {
+		return Quantity{value: value.Mul(value, pow10(exp)), format: DecimalSI}, nil
+	} else if shift, ok := binarySuffixes[suffix]; ok {
+		return Quantity{value: value.Mul(value, new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), shift))), format: BinarySI}, nil
+	} else if len(suffix) > 1 && (suffix[0] == 'e' || suffix[0] == 'E') {
+		if exp, err := strconv.Atoi(suffix[1:]); err == nil && exp >= -100 && exp <= 100 {
+			return Quantity{value: value.Mul(value, pow10(exp)), format: DecimalExponent}, nil
+		}
+	}
+	return Quantity{}, fmt.Errorf("quantity '%s' has an unknown unit '%s'", raw, suffix)
+}
+
+// MustParseQuantity is like ParseQuantity but panics if the quantity is invalid. It is intended for constants and
+// tests.
+func MustParseQuantity(raw string) Quantity {
+	q, err := ParseQuantity(raw)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// splitQuantity splits the raw string into the leading signed decimal number and the trailing unit suffix.
+func splitQuantity(raw string) (number string, suffix string) {
+	i := 0
+	if i < len(raw) && (raw[i] == '+' || raw[i] == '-') {
+		i++
+	}
+	for i < len(raw) && (raw[i] >= '0' && raw[i] <= '9' || raw[i] == '.') {
+		i++
+	}
+	return raw[:i], raw[i:]
+}
+
+// pow10 returns 10^exp as a rational.
+func pow10(exp int) *big.Rat {
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(exp))), nil)
+	if exp < 0 {
+		return new(big.Rat).SetFrac(big.NewInt(1), p)
+	}
+	return new(big.Rat).SetInt(p)
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// rat returns the value of the quantity, treating the zero value as 0.
+func (q Quantity) rat() *big.Rat {
+	if q.value == nil {
+		return new(big.Rat)
+	}
+	return q.value
+}
+
+// Format returns the suffix family used when formatting the quantity.
+func (q Quantity) Format() QuantityFormat {
+	if q.format == "" {
+		return DecimalSI
+	}
+	return q.format
+}
+
+// IsZero returns true if the quantity is equal to 0.
+func (q Quantity) IsZero() bool {
+	return q.rat().Sign() == 0
+}
+
+// Sign returns -1, 0, or 1 depending on the sign of the quantity.
+func (q Quantity) Sign() int {
+	return q.rat().Sign()
+}
+
+// Cmp compares the quantity with another and returns -1, 0, or 1 if it is less than, equal to, or greater than
+// the other quantity.
+func (q Quantity) Cmp(other Quantity) int {
+	return q.rat().Cmp(other.rat())
+}
+
+// Add returns the sum of the two quantities. The result uses the format of the receiver unless the receiver is the
+// zero value.
+func (q Quantity) Add(other Quantity) Quantity {
+	format := q.format
+	if format == "" {
+		format = other.format
+	}
+	return Quantity{value: new(big.Rat).Add(q.rat(), other.rat()), format: format}
+}
+
+// Sub returns the difference of the two quantities in the format of the receiver.
+func (q Quantity) Sub(other Quantity) Quantity {
+	format := q.format
+	if format == "" {
+		format = other.format
+	}
+	return Quantity{value: new(big.Rat).Sub(q.rat(), other.rat()), format: format}
+}
+
+// SumQuantities returns the sum of all the given quantities.
+func SumQuantities(quantities ...Quantity) Quantity {
+	var out Quantity
+	for _, q := range quantities {
+		out = out.Add(q)
+	}
+	return out
+}
+
+// ceilScaled returns the value multiplied by 10^exp, rounded away from zero to an integer.
+func (q Quantity) ceilScaled(exp int) *big.Int {
+	scaled := new(big.Rat).Mul(q.rat(), pow10(exp))
+	n, rem := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if rem.Sign() > 0 {
+		n.Add(n, big.NewInt(1))
+	} else if rem.Sign() < 0 {
+		n.Sub(n, big.NewInt(1))
+	}
+	return n
+}
+
+// Value returns the quantity as an integer, rounded up. For example 1.5Gi is 1610612736 and 500m is 1. Values that do
+// not fit in an int64 are clamped to math.MaxInt64 or math.MinInt64, see AsInt64 to detect this.
+func (q Quantity) Value() int64 {
+	return clampInt64(q.ceilScaled(0))
+}
+
+// MilliValue returns the quantity multiplied by 1000 as an integer, rounded up. For example 250m is 250 and 2 is
+// 2000. Values that do not fit in an int64 are clamped to math.MaxInt64 or math.MinInt64, see AsMilliInt64 to detect
+// this.
+func (q Quantity) MilliValue() int64 {
+	return clampInt64(q.ceilScaled(3))
+}
+
+// AsInt64 returns the Value of the quantity and true, or false if the value does not fit in an int64.
+func (q Quantity) AsInt64() (int64, bool) {
+	n := q.ceilScaled(0)
+	return n.Int64(), n.IsInt64()
+}
+
+// AsMilliInt64 returns the MilliValue of the quantity and true, or false if the value does not fit in an int64.
+func (q Quantity) AsMilliInt64() (int64, bool) {
+	n := q.ceilScaled(3)
+	return n.Int64(), n.IsInt64()
+}
+
+// clampInt64 returns the integer as an int64, clamped to the range of int64.
+func clampInt64(n *big.Int) int64 {
+	if n.IsInt64() {
+		return n.Int64()
+	} else if n.Sign() > 0 {
+		return math.MaxInt64
+	}
+	return math.MinInt64
+}
+
+// String returns the canonical representation of the quantity. The largest suffix of the quantity's format which
+// represents the value as an integer is used. Binary quantities that are not a whole number are formatted as decimal
+// quantities. For example 1.5Gi is 1536Mi, 1.5G is 1500M, and 0.5 is 500m.
+func (q Quantity) String() string {
+	if q.IsZero() {
+		return "0"
+	}
+	if q.Format() == BinarySI && q.rat().IsInt() {
+		n := new(big.Int).Set(q.rat().Num())
+		power := 0
+		mod := new(big.Int)
+		for power < len(binarySuffixByPower)-1 {
+			next, m := new(big.Int).QuoRem(n, big.NewInt(1024), mod)
+			if m.Sign() != 0 || next.Sign() == 0 {
+				break
+			}
+			n = next
+			power++
+		}
+		return n.String() + binarySuffixByPower[power]
+	}
+	nano := q.ceilScaled(9)
+	exp := -9
+	ten := big.NewInt(1000)
+	mod := new(big.Int)
+	for exp < 18 {
+		next, m := new(big.Int).QuoRem(nano, ten, mod)
+		if m.Sign() != 0 {
+			break
+		}
+		nano = next
+		exp += 3
+	}
+	if q.Format() == DecimalExponent {
+		if exp == 0 {
+			return nano.String()
+		}
+		return nano.String() + "e" + strconv.Itoa(exp)
+	}
+	return nano.String() + decimalSuffixByExponent[exp]
+}
+
+// MarshalJSON implements json.Marshaler.
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Both strings and numbers are accepted.
+func (q *Quantity) UnmarshalJSON(b []byte) error {
+	var raw string
+	if err := json.Unmarshal(b, &raw); err != nil {
+		raw = string(b)
+	}
+	parsed, err := ParseQuantity(raw)
+	if err != nil {
+		return err
+	}
+	*q = parsed
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (q Quantity) MarshalYAML() (interface{}, error) {
+	return q.String(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Both strings and numbers are accepted.
+func (q *Quantity) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		return fmt.Errorf("quantity must be a scalar value")
+	}
+	parsed, err := ParseQuantity(value.Value)
+	if err != nil {
+		return err
+	}
+	*q = parsed
+	return nil
+}
+
+// ParseResourceQuantities parses the cpu and memory of a resource limits definition into quantities if present.
+func ParseResourceQuantities(rl ResourcesLimits) (cpu *Quantity, memory *Quantity, err error) {
+	if rl.Cpu != nil {
+		q, err := ParseQuantity(*rl.Cpu)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cpu: %w", err)
+		}
+		cpu = &q
+	}
+	if rl.Memory != nil {
+		q, err := ParseQuantity(*rl.Memory)
+		if err != nil {
+			return nil, nil, fmt.Errorf("memory: %w", err)
+		}
+		memory = &q
+	}
+	return cpu, memory, nil
+}
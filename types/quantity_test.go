@@ -0,0 +1,179 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseQuantity(t *testing.T) {
+	for _, tc := range []struct {
+		raw        string
+		canonical  string
+		value      int64
+		milliValue int64
+	}{
+		{raw: "0", canonical: "0", value: 0, milliValue: 0},
+		{raw: "1", canonical: "1", value: 1, milliValue: 1000},
+		{raw: "+2", canonical: "2", value: 2, milliValue: 2000},
+		{raw: "-2", canonical: "-2", value: -2, milliValue: -2000},
+		{raw: "0.24", canonical: "240m", value: 1, milliValue: 240},
+		{raw: ".5", canonical: "500m", value: 1, milliValue: 500},
+		{raw: "1000m", canonical: "1", value: 1, milliValue: 1000},
+		{raw: "125m", canonical: "125m", value: 1, milliValue: 125},
+		{raw: "100u", canonical: "100u", value: 1, milliValue: 1},
+		{raw: "5n", canonical: "5n", value: 1, milliValue: 1},
+		{raw: "0.1n", canonical: "1n", value: 1, milliValue: 1},
+		{raw: "128M", canonical: "128M", value: 128000000, milliValue: 128000000000},
+		{raw: "1K", canonical: "1k", value: 1000, milliValue: 1000000},
+		{raw: "1.5G", canonical: "1500M", value: 1500000000, milliValue: 1500000000000},
+		{raw: "2P", canonical: "2P", value: 2000000000000000, milliValue: 2000000000000000000},
+		{raw: "1E", canonical: "1E", value: 1000000000000000000},
+		{raw: "1Ki", canonical: "1Ki", value: 1024, milliValue: 1024000},
+		{raw: "123Mi", canonical: "123Mi", value: 128974848, milliValue: 128974848000},
+		{raw: "1.5Gi", canonical: "1536Mi", value: 1610612736, milliValue: 1610612736000},
+		{raw: "2048Ki", canonical: "2Mi", value: 2097152, milliValue: 2097152000},
+		{raw: "1Pi", canonical: "1Pi", value: 1125899906842624, milliValue: 1125899906842624000},
+		{raw: "1Ei", canonical: "1Ei", value: 1152921504606846976},
+		{raw: "0.5Ki", canonical: "512", value: 512, milliValue: 512000},
+		{raw: "0.1Ki", canonical: "102400m", value: 103, milliValue: 102400},
+		{raw: "1e9", canonical: "1e9", value: 1000000000, milliValue: 1000000000000},
+		{raw: "1.5E3", canonical: "1500", value: 1500, milliValue: 1500000},
+		{raw: "12e-3", canonical: "12e-3", value: 1, milliValue: 12},
+		{raw: "1e+6", canonical: "1e6", value: 1000000, milliValue: 1000000000},
+	} {
+		t.Run(tc.raw, func(t *testing.T) {
+			q, err := ParseQuantity(tc.raw)
+			require.NoError(t, err)
+			assert.Equal(t, tc.canonical, q.String())
+			assert.Equal(t, tc.value, q.Value())
+			if tc.milliValue != 0 || tc.value == 0 {
+				assert.Equal(t, tc.milliValue, q.MilliValue())
+			}
+			reparsed, err := ParseQuantity(q.String())
+			require.NoError(t, err)
+			assert.Equal(t, q.String(), reparsed.String(), "canonical form must round trip")
+		})
+	}
+}
+
+func TestParseQuantity_errors(t *testing.T) {
+	for _, tc := range []struct {
+		raw     string
+		wantErr string
+	}{
+		{raw: "", wantErr: "quantity '' is not a number with an optional unit"},
+		{raw: "banana", wantErr: "quantity 'banana' is not a number with an optional unit"},
+		{raw: "Gi", wantErr: "quantity 'Gi' is not a number with an optional unit"},
+		{raw: "-", wantErr: "quantity '-' is not a number with an optional unit"},
+		{raw: "1.2.3", wantErr: "quantity '1.2.3' is not a number with an optional unit"},
+		{raw: "10 Gi", wantErr: "quantity '10 Gi' has an unknown unit ' Gi'"},
+		{raw: "10gi", wantErr: "quantity '10gi' has an unknown unit 'gi'"},
+		{raw: "1KiB", wantErr: "quantity '1KiB' has an unknown unit 'KiB'"},
+		{raw: "1e", wantErr: "quantity '1e' has an unknown unit 'e'"},
+		{raw: "1e1000", wantErr: "quantity '1e1000' has an unknown unit 'e1000'"},
+	} {
+		t.Run(tc.raw, func(t *testing.T) {
+			_, err := ParseQuantity(tc.raw)
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+	assert.Panics(t, func() {
+		MustParseQuantity("banana")
+	})
+}
+
+func TestQuantity_arithmetic(t *testing.T) {
+	var zero Quantity
+	assert.True(t, zero.IsZero())
+	assert.Equal(t, "0", zero.String())
+	assert.Equal(t, DecimalSI, zero.Format())
+
+	a := MustParseQuantity("1.5Gi")
+	b := MustParseQuantity("512Mi")
+	assert.Equal(t, "2Gi", a.Add(b).String())
+	assert.Equal(t, "1Gi", a.Sub(b).String())
+	assert.Equal(t, 1, a.Cmp(b))
+	assert.Equal(t, -1, b.Cmp(a))
+	assert.Equal(t, 0, MustParseQuantity("1Ki").Cmp(MustParseQuantity("1024")))
+	assert.Equal(t, -1, b.Sub(a).Sign())
+
+	// the format of the receiver is retained
+	assert.Equal(t, "1000001024", MustParseQuantity("1G").Add(MustParseQuantity("1Ki")).String())
+	assert.Equal(t, "750m", zero.Add(MustParseQuantity("250m")).Add(MustParseQuantity("0.5")).String())
+
+	assert.Equal(t, "2250m", SumQuantities(MustParseQuantity("1"), MustParseQuantity("1000m"), MustParseQuantity("250m")).String())
+	assert.Equal(t, "3Gi", SumQuantities(MustParseQuantity("1Gi"), MustParseQuantity("2Gi")).String())
+	assert.True(t, SumQuantities().IsZero())
+}
+
+func TestQuantity_overflow(t *testing.T) {
+	v, ok := MustParseQuantity("1Ei").AsInt64()
+	assert.True(t, ok)
+	assert.Equal(t, int64(1152921504606846976), v)
+	_, ok = MustParseQuantity("1E").AsMilliInt64()
+	assert.False(t, ok)
+	_, ok = MustParseQuantity("10Ei").AsInt64()
+	assert.False(t, ok)
+	_, ok = MustParseQuantity("100E").AsMilliInt64()
+	assert.False(t, ok)
+
+	assert.Equal(t, int64(math.MaxInt64), MustParseQuantity("10Ei").Value())
+	assert.Equal(t, int64(math.MinInt64), MustParseQuantity("-10Ei").Value())
+	assert.Equal(t, int64(math.MaxInt64), MustParseQuantity("100E").MilliValue())
+	assert.Equal(t, int64(math.MaxInt64), MustParseQuantity("1e30").Value())
+}
+
+func TestQuantity_marshalling(t *testing.T) {
+	type holder struct {
+		Cpu    Quantity `json:"cpu" yaml:"cpu"`
+		Memory Quantity `json:"memory" yaml:"memory"`
+	}
+
+	var fromJson holder
+	require.NoError(t, json.Unmarshal([]byte(`{"cpu": 0.5, "memory": "1.5Gi"}`), &fromJson))
+	raw, err := json.Marshal(fromJson)
+	require.NoError(t, err)
+	assert.Equal(t, `{"cpu":"500m","memory":"1536Mi"}`, string(raw))
+	assert.Error(t, json.Unmarshal([]byte(`{"cpu": "banana"}`), &fromJson))
+
+	var fromYaml holder
+	require.NoError(t, yaml.Unmarshal([]byte("cpu: 2\nmemory: 1e9\n"), &fromYaml))
+	raw, err = yaml.Marshal(fromYaml)
+	require.NoError(t, err)
+	assert.Equal(t, "cpu: \"2\"\nmemory: \"1e9\"\n", string(raw))
+	assert.EqualError(t, yaml.Unmarshal([]byte("cpu: [1]\n"), &fromYaml), "quantity must be a scalar value")
+}
+
+func TestParseResourceQuantities(t *testing.T) {
+	cpu, memory, err := ParseResourceQuantities(ResourcesLimits{Cpu: Ref("0.24"), Memory: Ref("1.5Gi")})
+	require.NoError(t, err)
+	assert.Equal(t, "240m", cpu.String())
+	assert.Equal(t, "1536Mi", memory.String())
+
+	cpu, memory, err = ParseResourceQuantities(ResourcesLimits{})
+	require.NoError(t, err)
+	assert.Nil(t, cpu)
+	assert.Nil(t, memory)
+
+	_, _, err = ParseResourceQuantities(ResourcesLimits{Memory: Ref("10 Gi")})
+	assert.EqualError(t, err, "memory: quantity '10 Gi' has an unknown unit ' Gi'")
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ResourceTotals holds the cpu and memory requests and limits of a container, or the sum of these across a number of
+// containers. Unset requests are treated as 0. An unset limit means that the container is unbounded, which is recorded
+// in LimitsCpuUnbounded or LimitsMemoryUnbounded while the corresponding limit only sums the limits that are set.
+type ResourceTotals struct {
+	RequestsCpu    Quantity `json:"requestsCpu" yaml:"requestsCpu"`
+	RequestsMemory Quantity `json:"requestsMemory" yaml:"requestsMemory"`
+	LimitsCpu      Quantity `json:"limitsCpu" yaml:"limitsCpu"`
+	LimitsMemory   Quantity `json:"limitsMemory" yaml:"limitsMemory"`
+	// LimitsCpuUnbounded is true if any of the containers has no cpu limit.
+	LimitsCpuUnbounded bool `json:"limitsCpuUnbounded,omitempty" yaml:"limitsCpuUnbounded,omitempty"`
+	// LimitsMemoryUnbounded is true if any of the containers has no memory limit.
+	LimitsMemoryUnbounded bool `json:"limitsMemoryUnbounded,omitempty" yaml:"limitsMemoryUnbounded,omitempty"`
+}
+
+// Add returns the sum of the two totals.
+func (t ResourceTotals) Add(other ResourceTotals) ResourceTotals {
+	return ResourceTotals{
+		RequestsCpu:    t.RequestsCpu.Add(other.RequestsCpu),
+		RequestsMemory: t.RequestsMemory.Add(other.RequestsMemory),
+		LimitsCpu:      t.LimitsCpu.Add(other.LimitsCpu),
+		LimitsMemory:   t.LimitsMemory.Add(other.LimitsMemory),
+
+		LimitsCpuUnbounded:    t.LimitsCpuUnbounded || other.LimitsCpuUnbounded,
+		LimitsMemoryUnbounded: t.LimitsMemoryUnbounded || other.LimitsMemoryUnbounded,
+	}
+}
+
+// ContainerResourceTotals parses the cpu and memory requests and limits of the container.
+func ContainerResourceTotals(c Container) (ResourceTotals, error) {
+	out := ResourceTotals{LimitsCpuUnbounded: true, LimitsMemoryUnbounded: true}
+	if c.Resources == nil {
+		return out, nil
+	}
+	if c.Resources.Requests != nil {
+		cpu, memory, err := ParseResourceQuantities(*c.Resources.Requests)
+		if err != nil {
+			return out, fmt.Errorf("requests: %w", err)
+		}
+		if cpu != nil {
+			out.RequestsCpu = *cpu
+		}
+		if memory != nil {
+			out.RequestsMemory = *memory
+		}
+	}
+	if c.Resources.Limits != nil {
+		cpu, memory, err := ParseResourceQuantities(*c.Resources.Limits)
+		if err != nil {
+			return out, fmt.Errorf("limits: %w", err)
+		}
+		if cpu != nil {
+			out.LimitsCpu = *cpu
+			out.LimitsCpuUnbounded = false
+		}
+		if memory != nil {
+			out.LimitsMemory = *memory
+			out.LimitsMemoryUnbounded = false
+		}
+	}
+	return out, nil
+}
+
+// WorkloadResourceTotals returns the per-container resource totals of the workload along with the sum across all
+// containers.
+func WorkloadResourceTotals(w *Workload) (total ResourceTotals, perContainer map[string]ResourceTotals, err error) {
+	perContainer = make(map[string]ResourceTotals, len(w.Containers))
+	names := make([]string, 0, len(w.Containers))
+	for name := range w.Containers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t, err := ContainerResourceTotals(w.Containers[name])
+		if err != nil {
+			return ResourceTotals{}, nil, fmt.Errorf("containers: %s: resources: %w", name, err)
+		}
+		perContainer[name] = t
+		total = total.Add(t)
+	}
+	return total, perContainer, nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkloadResourceTotals(t *testing.T) {
+	w := &Workload{
+		Containers: WorkloadContainers{
+			"a": {Image: "a", Resources: &ContainerResources{
+				Requests: &ResourcesLimits{Cpu: Ref("250m"), Memory: Ref("1Gi")},
+				Limits:   &ResourcesLimits{Cpu: Ref("1"), Memory: Ref("2Gi")},
+			}},
+			"b": {Image: "b", Resources: &ContainerResources{
+				Requests: &ResourcesLimits{Cpu: Ref("0.5"), Memory: Ref("512Mi")},
+			}},
+			"c": {Image: "c"},
+		},
+	}
+	total, perContainer, err := WorkloadResourceTotals(w)
+	require.NoError(t, err)
+	assert.Equal(t, "750m", total.RequestsCpu.String())
+	assert.Equal(t, "1536Mi", total.RequestsMemory.String())
+	assert.Equal(t, "1", total.LimitsCpu.String())
+	assert.Equal(t, "2Gi", total.LimitsMemory.String())
+	assert.True(t, total.LimitsCpuUnbounded, "containers without limits make the total unbounded")
+	assert.True(t, total.LimitsMemoryUnbounded)
+	assert.False(t, perContainer["a"].LimitsCpuUnbounded)
+	assert.False(t, perContainer["a"].LimitsMemoryUnbounded)
+	assert.Len(t, perContainer, 3)
+	assert.Equal(t, "500m", perContainer["b"].RequestsCpu.String())
+	assert.True(t, perContainer["b"].LimitsCpu.IsZero())
+	assert.Equal(t, ResourceTotals{LimitsCpuUnbounded: true, LimitsMemoryUnbounded: true}, perContainer["c"])
+
+	delete(w.Containers, "b")
+	delete(w.Containers, "c")
+	total, _, err = WorkloadResourceTotals(w)
+	require.NoError(t, err)
+	assert.False(t, total.LimitsCpuUnbounded)
+	assert.False(t, total.LimitsMemoryUnbounded)
+
+	w.Containers["c"] = Container{Image: "c", Resources: &ContainerResources{Requests: &ResourcesLimits{Cpu: Ref("x")}}}
+	_, _, err = WorkloadResourceTotals(w)
+	assert.EqualError(t, err, "containers: c: resources: requests: cpu: quantity 'x' is not a number with an optional unit")
+}
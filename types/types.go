@@ -16,8 +16,6 @@ package types
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 )
 
 //go:generate go run github.com/atombender/go-jsonschema@v0.15.0 -v --schema-output=https://score.dev/schemas/score=types.gen.go --schema-package=https://score.dev/schemas/score=types --schema-root-type=https://score.dev/schemas/score=Workload ../schema/files/score-v1b1.json.for-generation
@@ -40,51 +38,34 @@ func (m *WorkloadMetadata) UnmarshalYAML(unmarshal func(interface{}) error) erro
 	return nil
 }
 
-// findIPowerSuffix is used in ParseResourceLimits to parse memory units.
-func findIPowerSuffix(raw string, suffi []string, base int64) (suffix string, m int64) {
-	m = 1
-	for _, s := range suffi {
-		m *= base
-		if strings.HasSuffix(raw, s) {
-			return s, m
-		}
-	}
-	return "", 0
-}
-
 // ParseResourceLimits parses a resource limits definition into milli-cpus and memory bytes if present.
 // For example, 500m cpus = 500 millicpus, while 2 cpus = 2000 cpus. 1M == 1000000 bytes of memory, while 1Ki = 1024.
+// Values are parsed with ParseQuantity and rounded up, see ParseResourceQuantities to retain the parsed quantities.
+// An error is returned if a value does not fit in the result.
 func ParseResourceLimits(rl ResourcesLimits) (milliCpus *int, memoryBytes *int64, err error) {
 	if rl.Cpu != nil {
-		isMilli := strings.HasSuffix(*rl.Cpu, "m")
-		c := strings.TrimSuffix(*rl.Cpu, "m")
-		v, err := strconv.ParseFloat(c, 64)
+		q, err := ParseQuantity(*rl.Cpu)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to parse cpus '%s' as a number", c)
+			return nil, nil, fmt.Errorf("failed to parse cpus '%s' as a number", *rl.Cpu)
 		}
-		if !isMilli {
-			v *= 1000
+		v, ok := q.AsMilliInt64()
+		if iv := int(v); ok && int64(iv) == v {
+			milliCpus = &iv
+		} else {
+			return nil, nil, fmt.Errorf("cpus '%s' is too large", *rl.Cpu)
 		}
-		iv := int(v)
-		milliCpus = &iv
 	}
 	if rl.Memory != nil {
 		// https://kubernetes.io/docs/tasks/configure-pod-container/assign-memory-resource/#memory-units
-		raw := *rl.Memory
-		var multiplier int64 = 1
-		if s, m := findIPowerSuffix(raw, []string{"K", "M", "G", "T"}, 1000); m > 0 {
-			raw = strings.TrimSuffix(raw, s)
-			multiplier = m
-		} else if s, m = findIPowerSuffix(raw, []string{"Ki", "Mi", "Gi", "Ti"}, 1024); m > 0 {
-			raw = strings.TrimSuffix(raw, s)
-			multiplier = m
+		q, err := ParseQuantity(*rl.Memory)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse memory '%s' as a number", *rl.Memory)
 		}
-		if v, err := strconv.ParseInt(raw, 10, 64); err != nil {
-			return nil, nil, fmt.Errorf("failed to parse memory '%s' as a number", raw)
-		} else {
-			v *= multiplier
-			memoryBytes = &v
+		v, ok := q.AsInt64()
+		if !ok {
+			return nil, nil, fmt.Errorf("memory '%s' is too large", *rl.Memory)
 		}
+		memoryBytes = &v
 	}
 	return
 }
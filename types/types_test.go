@@ -43,4 +43,11 @@ func TestParseResourceLimits(t *testing.T) {
 	assert.Equal(t, "-1 -1 failed to parse cpus 'banana' as a number", parseAndFormatResourceLimits(ResourcesLimits{Cpu: Ref("banana"), Memory: nil}))
 	assert.Equal(t, "-1 -1 failed to parse memory 'banana' as a number", parseAndFormatResourceLimits(ResourcesLimits{Cpu: nil, Memory: Ref("banana")}))
 	assert.Equal(t, "200 128974848 <nil>", parseAndFormatResourceLimits(ResourcesLimits{Cpu: Ref("200m"), Memory: Ref("123Mi")}))
+	assert.Equal(t, "1500 1610612736 <nil>", parseAndFormatResourceLimits(ResourcesLimits{Cpu: Ref("1.5"), Memory: Ref("1.5Gi")}))
+	assert.Equal(t, "240 1000000000 <nil>", parseAndFormatResourceLimits(ResourcesLimits{Cpu: Ref("0.24"), Memory: Ref("1e9")}))
+	assert.Equal(t, "-1 2000000000000000 <nil>", parseAndFormatResourceLimits(ResourcesLimits{Memory: Ref("2P")}))
+	assert.Equal(t, "-1 -1 failed to parse memory '10 Gi' as a number", parseAndFormatResourceLimits(ResourcesLimits{Memory: Ref("10 Gi")}))
+	assert.Equal(t, "-1 -1 cpus '100E' is too large", parseAndFormatResourceLimits(ResourcesLimits{Cpu: Ref("100E")}))
+	assert.Equal(t, "-1 -1 memory '10Ei' is too large", parseAndFormatResourceLimits(ResourcesLimits{Memory: Ref("10Ei")}))
+	assert.Equal(t, "-1 -1 memory '-10Ei' is too large", parseAndFormatResourceLimits(ResourcesLimits{Memory: Ref("-10Ei")}))
 }
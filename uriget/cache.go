@@ -0,0 +1,287 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// cacheEntry is the metadata stored in the cache directory for each fetched uri. The file contents are stored
+// separately as blobs addressed by their sha256 digest so that identical files are only stored once.
+type cacheEntry struct {
+	Key string `json:"key"`
+	// Resolved is the git commit or oci manifest digest that the uri resolved to when it was fetched.
+	Resolved string `json:"resolved,omitempty"`
+	// Immutable is set when the uri pins the content itself, such as an oci reference by digest.
+	Immutable    bool        `json:"immutable,omitempty"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"lastModified,omitempty"`
+	FetchedAt    time.Time   `json:"fetchedAt"`
+	Files        []cacheFile `json:"files"`
+}
+
+type cacheFile struct {
+	URI      string `json:"uri"`
+	Digest   string `json:"digest"`
+	Revision string `json:"revision,omitempty"`
+}
+
+// fetchResult is returned by the fetch functions passed to fetchCached.
+type fetchResult struct {
+	files []FileContent
+	// notModified indicates that the previous cache entry is still current, files is empty in this case.
+	notModified  bool
+	resolved     string
+	immutable    bool
+	etag         string
+	lastModified string
+}
+
+// unavailableError marks errors caused by the remote being unreachable, a stale cache entry may be served instead.
+type unavailableError struct {
+	err error
+}
+
+func (e *unavailableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *unavailableError) Unwrap() error {
+	return e.err
+}
+
+// isUnavailable returns true if the error indicates that the remote could not be reached rather than that it
+// rejected the request.
+func isUnavailable(err error) bool {
+	var unavailable *unavailableError
+	var urlErr *url.Error
+	return errors.As(err, &unavailable) || errors.As(err, &urlErr)
+}
+
+// fetchCached returns the files for the given kind of fetch and uri. When no cache directory is configured, this
+// simply calls fetch. Otherwise, a fresh cache entry is returned directly, and a stale one is passed to fetch so
+// that it can be revalidated.
+func (o *options) fetchCached(kind string, uri string, fetch func(previous *cacheEntry) (*fetchResult, error)) ([]FileContent, error) {
+	if o.cacheDir == "" {
+		if o.offline {
+			return nil, fmt.Errorf("offline mode requires a cache directory")
+		}
+		res, err := fetch(nil)
+		if err != nil {
+			return nil, err
+		}
+		return res.files, nil
+	}
+
+	key := kind + " " + uri + o.scopeKey()
+	entry, cached := o.readCache(key)
+	if entry != nil && (o.offline || entry.Immutable || o.cacheTTL < 0 || time.Since(entry.FetchedAt) < o.cacheTTL) {
+		o.logger.Printf("Using cached content for %s fetched at %s", uri, entry.FetchedAt.Format(time.RFC3339))
+		return cached, nil
+	} else if o.offline {
+		return nil, fmt.Errorf("%s is not cached and offline mode is enabled", uri)
+	}
+
+	res, err := fetch(entry)
+	if err != nil {
+		if entry != nil && isUnavailable(err) {
+			o.logger.Printf("Using stale cached content for %s since the remote is unavailable: %v", uri, err)
+			return cached, nil
+		}
+		return nil, err
+	}
+	if res.notModified {
+		entry.FetchedAt = time.Now()
+		if err := o.writeCacheEntry(entry); err != nil {
+			o.logger.Printf("Warning: failed to update cache entry for %s: %v", uri, err)
+		}
+		o.logger.Printf("Cached content for %s is up to date", uri)
+		return cached, nil
+	}
+
+	entry = &cacheEntry{
+		Key:          key,
+		Resolved:     res.resolved,
+		Immutable:    res.immutable,
+		ETag:         res.etag,
+		LastModified: res.lastModified,
+		FetchedAt:    time.Now(),
+	}
+	if err := o.writeCache(entry, res.files); err != nil {
+		o.logger.Printf("Warning: failed to cache content for %s: %v", uri, err)
+	}
+	return res.files, nil
+}
+
+// scopeKey describes the credentials and accepted http status codes, so that content fetched with credentials or
+// accepted with a different status is only reused by callers with the same options. Credentials are hashed rather
+// than stored in the cache entry.
+func (o *options) scopeKey() string {
+	if len(o.httpHeaders) == 0 && !o.netrc && len(o.ociCredentials) == 0 && o.ociCredentialStore == nil && len(o.httpAcceptedStatusCodes) == 0 {
+		return ""
+	}
+	h := sha256.New()
+	for _, host := range slices.Sorted(maps.Keys(o.httpHeaders)) {
+		for _, header := range o.httpHeaders[host] {
+			value := header.value
+			if header.env != "" {
+				value += os.Getenv(header.env)
+			}
+			_, _ = fmt.Fprintf(h, "header %q %q %q\n", host, header.name, value)
+		}
+	}
+	if o.netrc {
+		path := o.netrcPath
+		if path == "" {
+			if path = os.Getenv("NETRC"); path == "" {
+				home, _ := os.UserHomeDir()
+				path = filepath.Join(home, ".netrc")
+			}
+		}
+		raw, _ := os.ReadFile(path)
+		_, _ = fmt.Fprintf(h, "netrc %q %x\n", path, sha256.Sum256(raw))
+	}
+	for _, registry := range slices.Sorted(maps.Keys(o.ociCredentials)) {
+		cred := o.ociCredentials[registry]
+		_, _ = fmt.Fprintf(h, "oci %q %q %q %q %q\n", registry, cred.Username, cred.Password, cred.RefreshToken, cred.AccessToken)
+	}
+	if o.ociCredentialStore != nil {
+		// a custom store cannot be identified across processes, so its cache entries are only reused by the same store
+		_, _ = fmt.Fprintf(h, "oci-store %p\n", o.ociCredentialStore)
+	}
+	codes := slices.Sorted(slices.Values(o.httpAcceptedStatusCodes))
+	_, _ = fmt.Fprintf(h, "status %v\n", codes)
+	return " scope=" + hex.EncodeToString(h.Sum(nil))
+}
+
+// readCache returns the entry and its files for the key, or nil if there is no usable entry.
+func (o *options) readCache(key string) (*cacheEntry, []FileContent) {
+	raw, err := os.ReadFile(o.cacheEntryPath(key))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			o.logger.Printf("Warning: failed to read cache entry for %s: %v", key, err)
+		}
+		return nil, nil
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil || entry.Key != key {
+		o.logger.Printf("Warning: ignoring invalid cache entry for %s", key)
+		return nil, nil
+	}
+	files := make([]FileContent, 0, len(entry.Files))
+	for _, f := range entry.Files {
+		content, err := o.readCacheBlob(f.Digest)
+		if err != nil {
+			o.logger.Printf("Warning: ignoring cache entry for %s: %v", key, err)
+			return nil, nil
+		}
+		files = append(files, FileContent{URI: f.URI, Content: content, Revision: f.Revision})
+	}
+	return &entry, files
+}
+
+func (o *options) readCacheBlob(digest string) ([]byte, error) {
+	p, err := o.cacheBlobPath(digest)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	content, err := readLimited(f, o.limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob %s: %w", digest, err)
+	} else if sha256Digest(content) != digest {
+		return nil, fmt.Errorf("blob %s does not match its digest", digest)
+	}
+	return content, nil
+}
+
+// writeCache stores the file contents as blobs and then writes the entry referencing them.
+func (o *options) writeCache(entry *cacheEntry, files []FileContent) error {
+	entry.Files = make([]cacheFile, 0, len(files))
+	for _, f := range files {
+		digest := sha256Digest(f.Content)
+		p, _ := o.cacheBlobPath(digest)
+		if _, err := os.Stat(p); err != nil {
+			if err := writeFileAtomic(p, f.Content); err != nil {
+				return err
+			}
+		}
+		entry.Files = append(entry.Files, cacheFile{URI: f.URI, Digest: digest, Revision: f.Revision})
+	}
+	return o.writeCacheEntry(entry)
+}
+
+func (o *options) writeCacheEntry(entry *cacheEntry) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(o.cacheEntryPath(entry.Key), raw)
+}
+
+func (o *options) cacheEntryPath(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return filepath.Join(o.cacheDir, "entries", hex.EncodeToString(h[:])+".json")
+}
+
+func (o *options) cacheBlobPath(digest string) (string, error) {
+	encoded, ok := strings.CutPrefix(digest, "sha256:")
+	if _, err := hex.DecodeString(encoded); !ok || err != nil || len(encoded) != sha256.Size*2 {
+		return "", fmt.Errorf("invalid blob digest '%s'", digest)
+	}
+	return filepath.Join(o.cacheDir, "blobs", "sha256", encoded), nil
+}
+
+func sha256Digest(content []byte) string {
+	h := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(h[:])
+}
+
+// writeFileAtomic writes the file through a temporary file and rename so that concurrent readers never observe a
+// partially written file.
+func writeFileAtomic(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := f.Write(content); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
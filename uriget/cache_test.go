@@ -0,0 +1,287 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testHttpFile serves a single file with an ETag and counts full and conditional responses.
+type testHttpFile struct {
+	lock        sync.Mutex
+	content     string
+	etag        string
+	full        int
+	notModified int
+}
+
+func (f *testHttpFile) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if r.Header.Get("If-None-Match") == f.etag {
+		f.notModified++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	f.full++
+	w.Header().Set("ETag", f.etag)
+	_, _ = w.Write([]byte(f.content))
+}
+
+func (f *testHttpFile) set(content, etag string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.content, f.etag = content, etag
+}
+
+func (f *testHttpFile) counts() (int, int) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.full, f.notModified
+}
+
+func TestGetFiles_CacheHttpRevalidation(t *testing.T) {
+	file := &testHttpFile{content: "v1", etag: `"1"`}
+	srv := httptest.NewServer(file)
+	defer srv.Close()
+	opts := []Option{WithCacheDir(t.TempDir()), WithLogger(log.New(os.Stderr, "", 0))}
+
+	for i, expected := range []string{"v1", "v1"} {
+		results, err := GetFiles(context.Background(), srv.URL+"/file.yaml", opts...)
+		if err != nil {
+			t.Fatalf("fetch %d: unexpected error: %v", i, err)
+		}
+		if len(results) != 1 || string(results[0].Content) != expected || results[0].URI != srv.URL+"/file.yaml" {
+			t.Fatalf("fetch %d: unexpected results: %+v", i, results)
+		}
+	}
+	if full, notModified := file.counts(); full != 1 || notModified != 1 {
+		t.Errorf("expected 1 full and 1 conditional response, got %d and %d", full, notModified)
+	}
+
+	file.set("v2", `"2"`)
+	buff, err := GetFile(context.Background(), srv.URL+"/file.yaml", opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(buff) != "v2" {
+		t.Errorf("expected updated content 'v2', got '%s'", buff)
+	}
+}
+
+func TestGetFiles_CacheTTL(t *testing.T) {
+	file := &testHttpFile{content: "v1", etag: `"1"`}
+	srv := httptest.NewServer(file)
+	defer srv.Close()
+	opts := []Option{WithCacheDir(t.TempDir()), WithCacheTTL(time.Hour), WithLogger(log.New(os.Stderr, "", 0))}
+
+	for i := 0; i < 3; i++ {
+		if _, err := GetFile(context.Background(), srv.URL, opts...); err != nil {
+			t.Fatalf("fetch %d: unexpected error: %v", i, err)
+		}
+	}
+	if full, notModified := file.counts(); full != 1 || notModified != 0 {
+		t.Errorf("expected a single request within the ttl, got %d full and %d conditional", full, notModified)
+	}
+}
+
+func TestGetFiles_CacheOffline(t *testing.T) {
+	file := &testHttpFile{content: "v1", etag: `"1"`}
+	srv := httptest.NewServer(file)
+	cacheDir := t.TempDir()
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	_, err := GetFile(context.Background(), srv.URL, WithCacheDir(cacheDir), WithOffline(true), logger)
+	if err == nil || !strings.Contains(err.Error(), "is not cached and offline mode is enabled") {
+		t.Fatalf("expected an offline cache miss error, got %v", err)
+	}
+	if _, err := GetFile(context.Background(), srv.URL, WithCacheDir(cacheDir), logger); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	srv.Close()
+
+	buff, err := GetFile(context.Background(), srv.URL, WithCacheDir(cacheDir), WithOffline(true), logger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(buff) != "v1" {
+		t.Errorf("expected cached content 'v1', got '%s'", buff)
+	}
+
+	// the remote being unreachable falls back to the stale entry
+	buff, err = GetFile(context.Background(), srv.URL, WithCacheDir(cacheDir), logger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(buff) != "v1" {
+		t.Errorf("expected stale content 'v1', got '%s'", buff)
+	}
+
+	_, err = GetFile(context.Background(), srv.URL, WithOffline(true), logger)
+	if err == nil || err.Error() != "offline mode requires a cache directory" {
+		t.Errorf("expected missing cache directory error, got %v", err)
+	}
+}
+
+func TestGetFiles_CacheDoesNotHideErrors(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("v1"))
+	}))
+	defer srv.Close()
+	opts := []Option{WithCacheDir(t.TempDir()), WithLogger(log.New(os.Stderr, "", 0))}
+	if _, err := GetFile(context.Background(), srv.URL, opts...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	status = http.StatusNotFound
+	if _, err := GetFile(context.Background(), srv.URL, opts...); err == nil || !strings.Contains(err.Error(), "non-200 status code: 404") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	status = http.StatusBadGateway
+	if buff, err := GetFile(context.Background(), srv.URL, opts...); err != nil || string(buff) != "v1" {
+		t.Errorf("expected stale content on server error, got '%s' %v", buff, err)
+	}
+}
+
+func TestGetFiles_CacheCorruptBlob(t *testing.T) {
+	file := &testHttpFile{content: "v1", etag: `"1"`}
+	srv := httptest.NewServer(file)
+	defer srv.Close()
+	cacheDir := t.TempDir()
+	opts := []Option{WithCacheDir(cacheDir), WithLogger(log.New(os.Stderr, "", 0))}
+	if _, err := GetFile(context.Background(), srv.URL, opts...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	blobs, err := filepath.Glob(filepath.Join(cacheDir, "blobs", "sha256", "*"))
+	if err != nil || len(blobs) != 1 {
+		t.Fatalf("expected a single blob, got %v %v", blobs, err)
+	}
+	if err := os.WriteFile(blobs[0], []byte("tampered"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	buff, err := GetFile(context.Background(), srv.URL, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(buff) != "v1" {
+		t.Errorf("expected refetched content 'v1', got '%s'", buff)
+	}
+	if full, _ := file.counts(); full != 2 {
+		t.Errorf("expected the corrupt entry to be refetched, got %d full responses", full)
+	}
+}
+
+func TestGetFiles_CacheGit(t *testing.T) {
+	repo := newTestGitRepo(t, map[string]string{"dir/a.yaml": "a1", "dir/b.yaml": "b1"})
+	opts := []Option{WithCacheDir(t.TempDir()), WithLogger(log.New(os.Stderr, "", 0))}
+
+	results, err := GetFiles(context.Background(), repo.uri+"/dir", opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 || results[0].URI != "a.yaml" || string(results[0].Content) != "a1" {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	afterCheckout := repo.requests.Load()
+
+	results, err = GetFiles(context.Background(), repo.uri+"/dir", opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 || string(results[1].Content) != "b1" {
+		t.Fatalf("unexpected cached results: %+v", results)
+	}
+	if revalidation := repo.requests.Load() - afterCheckout; revalidation >= afterCheckout {
+		t.Errorf("expected revalidation to use fewer requests than a checkout, got %d and %d", revalidation, afterCheckout)
+	}
+
+	repo.commit(t, map[string]string{"dir/a.yaml": "a2"})
+	buff, err := GetFile(context.Background(), repo.uri+"/dir/a.yaml", opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(buff) != "a2" {
+		t.Errorf("expected content 'a2', got '%s'", buff)
+	}
+	results, err = GetFiles(context.Background(), repo.uri+"/dir", opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(results[0].Content) != "a2" {
+		t.Errorf("expected content 'a2' after the remote changed, got '%s'", results[0].Content)
+	}
+}
+
+func TestGetFiles_CacheScopedByCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("not found page"))
+		case r.Header.Get("Authorization") == "Bearer secret":
+			_, _ = w.Write([]byte("private"))
+		default:
+			_, _ = w.Write([]byte("public"))
+		}
+	}))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+	cacheDir := t.TempDir()
+	opts := []Option{WithCacheDir(cacheDir), WithCacheTTL(time.Hour), WithLogger(log.New(os.Stderr, "", 0))}
+
+	for _, tt := range []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{"bearer token", []Option{WithHttpBearerToken(host, "secret")}, "private"},
+		{"anonymous", nil, "public"},
+		{"bearer token again", []Option{WithHttpBearerToken(host, "secret")}, "private"},
+		{"other token", []Option{WithHttpBearerToken(host, "other")}, "public"},
+	} {
+		buff, err := GetFile(context.Background(), srv.URL+"/file.yaml", append(opts, tt.opts...)...)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if string(buff) != tt.expected {
+			t.Errorf("%s: expected '%s', got '%s'", tt.name, tt.expected, buff)
+		}
+	}
+
+	_, err := GetFile(context.Background(), srv.URL+"/file.yaml", WithCacheDir(cacheDir), WithOffline(true), WithHttpBasicAuth(host, "user", "pass"))
+	if err == nil || !strings.Contains(err.Error(), "is not cached and offline mode is enabled") {
+		t.Errorf("expected an offline cache miss for other credentials, got %v", err)
+	}
+
+	buff, err := GetFile(context.Background(), srv.URL+"/missing", append(opts, WithHttpAcceptedStatusCodes(http.StatusOK, http.StatusNotFound))...)
+	if err != nil || string(buff) != "not found page" {
+		t.Fatalf("expected the 404 body, got '%s' and %v", buff, err)
+	}
+	if _, err := GetFile(context.Background(), srv.URL+"/missing", opts...); err == nil {
+		t.Errorf("expected an error for the 404 response without the accepted status codes")
+	}
+}
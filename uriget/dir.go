@@ -0,0 +1,165 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// WithRecursive enables reading files in nested directories when GetFiles is given a directory.
+func WithRecursive(recursive bool) Option {
+	return func(o *options) {
+		o.recursive = recursive
+	}
+}
+
+// WithInclude restricts the files returned by GetFiles for a directory to those whose relative path matches at least
+// one of the glob patterns. Patterns use the path.Match syntax, and a "**" path segment matches zero or more
+// directories, for example "**/*.provisioners.yaml".
+func WithInclude(patterns ...string) Option {
+	return func(o *options) {
+		o.include = append(o.include, patterns...)
+	}
+}
+
+// WithExclude removes the files returned by GetFiles for a directory whose relative path matches any of the glob
+// patterns. Nested directories matching a pattern are skipped entirely. See WithInclude for the pattern syntax.
+func WithExclude(patterns ...string) Option {
+	return func(o *options) {
+		o.exclude = append(o.exclude, patterns...)
+	}
+}
+
+// validateGlobs returns an error if any of the include or exclude patterns is malformed.
+func (o *options) validateGlobs() error {
+	for _, group := range []struct {
+		kind     string
+		patterns []string
+	}{{"include", o.include}, {"exclude", o.exclude}} {
+		for _, pattern := range group.patterns {
+			for _, segment := range strings.Split(pattern, "/") {
+				if _, err := path.Match(segment, ""); err != nil {
+					return fmt.Errorf("invalid %s pattern '%s': %w", group.kind, pattern, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// dirKey describes the options that change the files read from a directory, so that cached directory listings are
+// only reused with the same options.
+func (o *options) dirKey() string {
+	if !o.recursive && len(o.include) == 0 && len(o.exclude) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" recursive=%v include=%q exclude=%q", o.recursive, o.include, o.exclude)
+}
+
+// readDir reads the files within the root directory sorted by their slash separated path relative to the root, which
+// is also used as their URI. Nested directories are only read in recursive mode. The name is used in error messages.
+func (o *options) readDir(root string, name string) ([]FileContent, error) {
+	var relPaths []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if p == root {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if !o.recursive || matchesAnyGlob(o.exclude, rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if (len(o.include) == 0 || matchesAnyGlob(o.include, rel)) && !matchesAnyGlob(o.exclude, rel) {
+			relPaths = append(relPaths, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+	sort.Strings(relPaths)
+
+	if len(relPaths) == 0 {
+		if len(o.include) > 0 || len(o.exclude) > 0 {
+			return nil, fmt.Errorf("directory %s contains no files matching the include and exclude patterns", name)
+		}
+		return nil, fmt.Errorf("directory %s contains no files", name)
+	}
+
+	out := make([]FileContent, 0, len(relPaths))
+	for _, rel := range relPaths {
+		filePath := filepath.Join(root, filepath.FromSlash(rel))
+		f, err := os.Open(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", filePath, err)
+		}
+		buff, err := readLimited(f, o.limit)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+		o.logger.Printf("Read %d bytes from %s", len(buff), filePath)
+		out = append(out, FileContent{URI: rel, Content: buff})
+	}
+	return out, nil
+}
+
+func matchesAnyGlob(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matchGlob(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob reports whether the slash separated name matches the pattern. In addition to the path.Match syntax, a
+// "**" path segment matches zero or more directories.
+func matchGlob(pattern string, name string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchGlobSegments(pattern []string, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlobSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		} else if len(name) == 0 {
+			return false
+		} else if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
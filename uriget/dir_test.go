@@ -0,0 +1,177 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var nestedFiles = map[string]string{
+	"10-base.provisioners.yaml":                "base",
+	"README.md":                                "readme",
+	"dns/20-dns.provisioners.yaml":             "dns",
+	"dns/score-k8s/30-dns.provisioners.yaml":   "dns-k8s",
+	"tests/40-broken.provisioners.yaml":        "broken",
+	"volumes/notes.txt":                        "notes",
+	"volumes/50-volume.provisioners.yaml":      "volume",
+	"volumes/extra/60-volume.provisioners.yml": "volume-yml",
+}
+
+func writeNestedFiles(t *testing.T) string {
+	t.Helper()
+	td := t.TempDir()
+	for name, content := range nestedFiles {
+		p := filepath.Join(td, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return td
+}
+
+func uris(files []FileContent) []string {
+	out := make([]string, len(files))
+	for i, f := range files {
+		out[i] = f.URI
+	}
+	return out
+}
+
+var directoryFilterTests = []struct {
+	name     string
+	opts     []Option
+	expected []string
+}{
+	{"top level", nil, []string{"10-base.provisioners.yaml", "README.md"}},
+	{"recursive", []Option{WithRecursive(true)}, []string{
+		"10-base.provisioners.yaml", "README.md", "dns/20-dns.provisioners.yaml", "dns/score-k8s/30-dns.provisioners.yaml",
+		"tests/40-broken.provisioners.yaml", "volumes/50-volume.provisioners.yaml", "volumes/extra/60-volume.provisioners.yml",
+		"volumes/notes.txt",
+	}},
+	{"include", []Option{WithRecursive(true), WithInclude("**/*.provisioners.yaml")}, []string{
+		"10-base.provisioners.yaml", "dns/20-dns.provisioners.yaml", "dns/score-k8s/30-dns.provisioners.yaml",
+		"tests/40-broken.provisioners.yaml", "volumes/50-volume.provisioners.yaml",
+	}},
+	{"include multiple", []Option{WithRecursive(true), WithInclude("**/*.provisioners.yaml", "**/*.provisioners.yml")}, []string{
+		"10-base.provisioners.yaml", "dns/20-dns.provisioners.yaml", "dns/score-k8s/30-dns.provisioners.yaml",
+		"tests/40-broken.provisioners.yaml", "volumes/50-volume.provisioners.yaml", "volumes/extra/60-volume.provisioners.yml",
+	}},
+	{"exclude directory", []Option{WithRecursive(true), WithInclude("**/*.provisioners.yaml"), WithExclude("tests", "dns/score-*")}, []string{
+		"10-base.provisioners.yaml", "dns/20-dns.provisioners.yaml", "volumes/50-volume.provisioners.yaml",
+	}},
+	{"include without recursion", []Option{WithInclude("*.md")}, []string{"README.md"}},
+}
+
+func TestGetFiles_DirectoryFilters(t *testing.T) {
+	td := writeNestedFiles(t)
+	for _, tt := range directoryFilterTests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := GetFiles(context.Background(), td, append(tt.opts, WithLogger(log.New(os.Stderr, "", 0)))...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := uris(results); strings.Join(actual, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+			for _, r := range results {
+				if string(r.Content) != nestedFiles[r.URI] {
+					t.Errorf("unexpected content for %s: '%s'", r.URI, r.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestGetFiles_DirectoryFilterErrors(t *testing.T) {
+	td := writeNestedFiles(t)
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	_, err := GetFiles(context.Background(), td, WithInclude("*.json"), logger)
+	if err == nil || err.Error() != "directory "+td+" contains no files matching the include and exclude patterns" {
+		t.Errorf("expected no matching files error, got %v", err)
+	}
+	_, err = GetFiles(context.Background(), td, WithExclude("[a-"), logger)
+	if err == nil || !strings.HasPrefix(err.Error(), "invalid exclude pattern '[a-'") {
+		t.Errorf("expected invalid pattern error, got %v", err)
+	}
+
+	// filters do not apply when the uri is a single file
+	results, err := GetFiles(context.Background(), filepath.Join(td, "README.md"), WithInclude("*.yaml"), logger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || string(results[0].Content) != "readme" || results[0].URI != filepath.Join(td, "README.md") {
+		t.Errorf("unexpected results: %+v", results)
+	}
+}
+
+func TestGetFiles_GitDirectoryFilters(t *testing.T) {
+	files := make(map[string]string, len(nestedFiles))
+	for name, content := range nestedFiles {
+		files["library/"+name] = content
+	}
+	files["other/ignored.provisioners.yaml"] = "ignored"
+	repo := newTestGitRepo(t, files)
+	cacheDir := t.TempDir()
+
+	for _, tt := range directoryFilterTests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append(tt.opts, WithCacheDir(cacheDir), WithLogger(log.New(os.Stderr, "", 0)))
+			// the second fetch is served from the cache, which must be keyed by the filters
+			for i := 0; i < 2; i++ {
+				results, err := GetFiles(context.Background(), repo.uri+"/library/", opts...)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if actual := uris(results); strings.Join(actual, ",") != strings.Join(tt.expected, ",") {
+					t.Errorf("fetch %d: expected %v, got %v", i, tt.expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	for _, tt := range []struct {
+		pattern  string
+		name     string
+		expected bool
+	}{
+		{"*.yaml", "a.yaml", true},
+		{"*.yaml", "dir/a.yaml", false},
+		{"**/*.yaml", "a.yaml", true},
+		{"**/*.yaml", "dir/nested/a.yaml", true},
+		{"dir/**", "dir/nested/a.yaml", true},
+		{"dir/**", "other/a.yaml", false},
+		{"dir/**/a.yaml", "dir/a.yaml", true},
+		{"dir/**/a.yaml", "dir/x/y/a.yaml", true},
+		{"dir/**/a.yaml", "dir/x/y/b.yaml", false},
+		{"dir/*", "dir/nested/a.yaml", false},
+		{"**", "anything/at/all", true},
+		{"a?.yaml", "ab.yaml", true},
+	} {
+		if actual := matchGlob(tt.pattern, tt.name); actual != tt.expected {
+			t.Errorf("matchGlob(%q, %q): expected %v, got %v", tt.pattern, tt.name, tt.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+// The errors returned by GetFile, GetFiles and PutFiles can be matched against these sentinels with errors.Is. The
+// error messages are not changed by the sentinels.
+var (
+	// ErrNotFound indicates that the file, directory, repository, ref, artifact or layer does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized indicates that the remote rejected the request because credentials are missing or invalid.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrLimitExceeded indicates that the content is larger than the limit set by WithLimit.
+	ErrLimitExceeded = errors.New("limit exceeded")
+	// ErrUnsupportedScheme indicates that there is no built-in or custom getter for the scheme of the uri.
+	ErrUnsupportedScheme = errors.New("unsupported scheme")
+	// ErrAmbiguousArtifact indicates that the uri contains multiple files where a single file is required, such as an
+	// oci artifact with multiple yaml layers and no fragment passed to GetFile.
+	ErrAmbiguousArtifact = errors.New("ambiguous artifact")
+)
+
+// sentinelError attaches a sentinel to an error while keeping its message.
+type sentinelError struct {
+	sentinel error
+	err      error
+}
+
+func (e *sentinelError) Error() string {
+	return e.err.Error()
+}
+
+func (e *sentinelError) Unwrap() []error {
+	return []error{e.err, e.sentinel}
+}
+
+// withSentinel returns the error marked with the sentinel. The error is returned as is if either is nil.
+func withSentinel(sentinel error, err error) error {
+	if err == nil || sentinel == nil {
+		return err
+	}
+	return &sentinelError{sentinel: sentinel, err: err}
+}
+
+// limitExceeded returns the error for content larger than the limit.
+func limitExceeded(limit int) error {
+	return withSentinel(ErrLimitExceeded, fmt.Errorf("%d byte limit exceeded", limit))
+}
+
+// fileError marks errors from the local file system for paths that do not exist.
+func fileError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return withSentinel(ErrNotFound, err)
+	}
+	return err
+}
+
+// httpStatusError marks the error for a response status code that indicates a missing file or credentials.
+func httpStatusError(code int, err error) error {
+	switch code {
+	case http.StatusNotFound, http.StatusGone:
+		return withSentinel(ErrNotFound, err)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return withSentinel(ErrUnauthorized, err)
+	}
+	return err
+}
+
+// ociError marks errors from the oci registry client that indicate a missing manifest or blob, or missing credentials.
+func ociError(err error) error {
+	var errResp *errcode.ErrorResponse
+	if errors.Is(err, auth.ErrBasicCredentialNotFound) {
+		return withSentinel(ErrUnauthorized, err)
+	} else if errors.As(err, &errResp) {
+		return httpStatusError(errResp.StatusCode, err)
+	} else if errors.Is(err, errdef.ErrNotFound) {
+		return withSentinel(ErrNotFound, err)
+	}
+	return err
+}
+
+// gitSentinel returns the sentinel for the output of a failed git command by matching the messages that git prints
+// for failed authentication and missing repositories or refs, or nil if the output matches neither.
+func gitSentinel(output []byte) error {
+	lower := strings.ToLower(string(output))
+	for _, pattern := range []string{
+		"authentication failed", "could not read username", "could not read password", "permission denied",
+		"terminal prompts disabled", "returned error: 401", "returned error: 403",
+	} {
+		if strings.Contains(lower, pattern) {
+			return ErrUnauthorized
+		}
+	}
+	for _, pattern := range []string{
+		"not found", "does not appear to be a git repository", "couldn't find remote ref", "returned error: 404",
+	} {
+		if strings.Contains(lower, pattern) {
+			return ErrNotFound
+		}
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var sentinels = []error{ErrNotFound, ErrUnauthorized, ErrLimitExceeded, ErrUnsupportedScheme, ErrAmbiguousArtifact}
+
+// checkSentinel fails the test unless the error matches the expected sentinel and no other.
+func checkSentinel(t *testing.T, err error, expected error) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("expected an error matching '%v'", expected)
+	}
+	for _, sentinel := range sentinels {
+		if matches := errors.Is(err, sentinel); matches != (sentinel == expected) {
+			t.Errorf("expected errors.Is(%v, %v) to be %v", err, sentinel, !matches)
+		}
+	}
+}
+
+func TestSentinels_Http(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/private":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+		case "/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			_, _ = w.Write([]byte("content"))
+		}
+	}))
+	defer srv.Close()
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	for _, tt := range []struct {
+		path     string
+		opts     []Option
+		expected error
+	}{
+		{"/missing", nil, ErrNotFound},
+		{"/private", nil, ErrUnauthorized},
+		{"/forbidden", nil, ErrUnauthorized},
+		{"/broken", nil, nil},
+		{"/large", []Option{WithLimit(3)}, ErrLimitExceeded},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			_, err := GetFiles(context.Background(), srv.URL+tt.path, append(tt.opts, logger)...)
+			checkSentinel(t, err, tt.expected)
+		})
+	}
+}
+
+func TestSentinels_File(t *testing.T) {
+	td := t.TempDir()
+	if err := os.WriteFile(filepath.Join(td, "a.yaml"), []byte("content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	_, err := GetFile(context.Background(), filepath.Join(td, "missing.yaml"), logger)
+	checkSentinel(t, err, ErrNotFound)
+	if !errors.Is(err, fs.ErrNotExist) || !strings.HasPrefix(err.Error(), "open ") {
+		t.Errorf("expected the original error to be kept, got %v", err)
+	}
+	_, err = GetFiles(context.Background(), "file://"+filepath.Join(td, "missing"), logger)
+	checkSentinel(t, err, ErrNotFound)
+	_, err = GetFiles(context.Background(), td, WithLimit(3), logger)
+	checkSentinel(t, err, ErrLimitExceeded)
+	_, err = GetFile(context.Background(), filepath.Join(td, "a.yaml"), WithLimit(3), logger)
+	checkSentinel(t, err, ErrLimitExceeded)
+	if err.Error() != "failed to read file: 3 byte limit exceeded" {
+		t.Errorf("expected the message to be unchanged, got %v", err)
+	}
+	_, err = GetFiles(context.Background(), td, WithExpectedDigest(helloDigest), WithRecursive(true), logger)
+	checkSentinel(t, err, nil)
+}
+
+func TestSentinels_Schemes(t *testing.T) {
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	_, err := GetFile(context.Background(), "s3://bucket/a.yaml", logger)
+	checkSentinel(t, err, ErrUnsupportedScheme)
+	_, err = GetFiles(context.Background(), "s3://bucket/a.yaml", logger)
+	checkSentinel(t, err, ErrUnsupportedScheme)
+	_, err = PutFiles(context.Background(), "s3://bucket/a.yaml", []FileContent{{URI: "a.yaml"}}, logger)
+	checkSentinel(t, err, ErrUnsupportedScheme)
+
+	_, err = GetFile(context.Background(), "mem://bucket", WithScheme("mem", testBucket), logger)
+	checkSentinel(t, err, ErrAmbiguousArtifact)
+	_, err = GetFile(context.Background(), "env:TEST_URIGET_UNSET", logger)
+	checkSentinel(t, err, ErrNotFound)
+	_, err = GetFile(context.Background(), "data:,hello", WithLimit(1), logger)
+	checkSentinel(t, err, ErrLimitExceeded)
+}
+
+func TestSentinels_Oci(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	reg := newTestRegistry(t)
+	reg.push(t, "provisioners", "v1",
+		FileContent{URI: "a.yaml", Content: []byte("a")},
+		FileContent{URI: "b.yaml", Content: []byte("b")},
+	)
+	uri := "oci://" + reg.host + "/provisioners"
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	_, err := GetFile(context.Background(), uri+":v1", logger)
+	checkSentinel(t, err, ErrAmbiguousArtifact)
+	_, err = GetFile(context.Background(), uri+":v1#c.yaml", logger)
+	checkSentinel(t, err, ErrNotFound)
+	_, err = GetFiles(context.Background(), uri+":v1#c.yaml", logger)
+	checkSentinel(t, err, ErrNotFound)
+	_, err = GetFiles(context.Background(), uri+":missing", logger)
+	checkSentinel(t, err, ErrNotFound)
+	_, err = GetFiles(context.Background(), uri+":v1", WithLimit(1), logger)
+	checkSentinel(t, err, ErrLimitExceeded)
+
+	reg.username, reg.password = "user", "secret"
+	_, err = GetFiles(context.Background(), uri+":v1", logger)
+	checkSentinel(t, err, ErrUnauthorized)
+	_, err = GetFiles(context.Background(), uri+":v1", WithOciCredentials(reg.host, "user", "wrong"), logger)
+	checkSentinel(t, err, ErrUnauthorized)
+	_, err = PutFiles(context.Background(), uri+":v2", []FileContent{{URI: "a.yaml"}}, logger)
+	checkSentinel(t, err, ErrUnauthorized)
+}
+
+func TestSentinels_Git(t *testing.T) {
+	t.Setenv("GIT_TERMINAL_PROMPT", "0")
+	repo := newTestGitRepo(t, map[string]string{"dir/a.yaml": "content"})
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	for _, tt := range []struct {
+		name     string
+		uri      string
+		opts     []Option
+		expected error
+	}{
+		{"missing repository", strings.Replace(repo.uri, "repo.git", "other.git", 1) + "/dir", nil, ErrNotFound},
+		{"missing ref", repo.uri + "/dir?ref=missing", nil, ErrNotFound},
+		{"missing path", repo.uri + "/other", nil, ErrNotFound},
+		{"limit", repo.uri + "/dir", []Option{WithLimit(3)}, ErrLimitExceeded},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GetFiles(context.Background(), tt.uri, append(tt.opts, logger)...)
+			checkSentinel(t, err, tt.expected)
+		})
+	}
+	_, err := GetFile(context.Background(), repo.uri+"/dir/missing.yaml", logger)
+	checkSentinel(t, err, ErrNotFound)
+
+	private := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="git"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer private.Close()
+	_, err = GetFiles(context.Background(), "git-https://"+private.Listener.Addr().String()+"/repo.git/dir", logger)
+	checkSentinel(t, err, ErrUnauthorized)
+}
+
+func TestGitSentinel(t *testing.T) {
+	for _, tt := range []struct {
+		output   string
+		expected error
+	}{
+		{"fatal: Authentication failed for 'https://example.com/repo.git/'", ErrUnauthorized},
+		{"fatal: could not read Username for 'https://example.com': terminal prompts disabled", ErrUnauthorized},
+		{"git@example.com: Permission denied (publickey).", ErrUnauthorized},
+		{"remote: Repository not found.\nfatal: repository 'https://example.com/repo.git/' not found", ErrNotFound},
+		{"fatal: couldn't find remote ref missing", ErrNotFound},
+		{"fatal: unable to access 'https://example.com/': Could not resolve host: example.com", nil},
+	} {
+		if actual := gitSentinel([]byte(tt.output)); actual != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.output, tt.expected, actual)
+		}
+	}
+}
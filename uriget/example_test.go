@@ -213,14 +213,14 @@ func TestGetFiles_Directory(t *testing.T) {
 	if len(results) != 3 {
 		t.Fatalf("expected 3 results, got %d", len(results))
 	}
-	// Verify sorted order and full path URIs
+	// Verify sorted order and relative path URIs
 	expected := []struct {
 		uri     string
 		content string
 	}{
-		{filepath.Join(td, "a-provisioner.yaml"), "content-a"},
-		{filepath.Join(td, "b-provisioner.yaml"), "content-b"},
-		{filepath.Join(td, "c-provisioner.yaml"), "content-c"},
+		{"a-provisioner.yaml", "content-a"},
+		{"b-provisioner.yaml", "content-b"},
+		{"c-provisioner.yaml", "content-c"},
 	}
 	for i, e := range expected {
 		if results[i].URI != e.uri {
@@ -247,8 +247,8 @@ func TestGetFiles_DirectorySkipsSubdirs(t *testing.T) {
 	if len(results) != 1 {
 		t.Fatalf("expected 1 result (subdir skipped), got %d", len(results))
 	}
-	if results[0].URI != filepath.Join(td, "file.yaml") {
-		t.Errorf("expected URI '%s', got '%s'", filepath.Join(td, "file.yaml"), results[0].URI)
+	if results[0].URI != "file.yaml" {
+		t.Errorf("expected URI 'file.yaml', got '%s'", results[0].URI)
 	}
 }
 
@@ -282,7 +282,7 @@ func TestGetFiles_DirectoryWithFileScheme(t *testing.T) {
 	if len(results) != 2 {
 		t.Fatalf("expected 2 results, got %d", len(results))
 	}
-	if results[0].URI != filepath.Join(td, "a.yaml") || results[1].URI != filepath.Join(td, "b.yaml") {
+	if results[0].URI != "a.yaml" || results[1].URI != "b.yaml" {
 		t.Errorf("unexpected URIs: %s, %s", results[0].URI, results[1].URI)
 	}
 }
@@ -340,12 +340,9 @@ func TestGetFiles_GitDirectorySkipsSubdirs(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	for _, r := range results {
-		// URI should be a file path relative to the subPath, not contain nested dirs
-		if strings.Contains(r.URI, "/") && strings.HasPrefix(r.URI, "dns/") {
-			parts := strings.Split(strings.TrimPrefix(r.URI, "dns/"), "/")
-			if len(parts) > 1 {
-				t.Errorf("expected only immediate files, got nested path: %s", r.URI)
-			}
+		// URI should be a file path relative to the directory, not contain nested dirs
+		if strings.Contains(r.URI, "/") {
+			t.Errorf("expected only immediate files, got nested path: %s", r.URI)
 		}
 	}
 }
@@ -393,3 +390,21 @@ func TestParseGitUrl(t *testing.T) {
 		})
 	}
 }
+
+func ExampleGetFile_data() {
+	buff, err := GetFile(context.Background(), "data:text/yaml;base64,a2V5OiB2YWx1ZQ==")
+	fmt.Println(string(buff), err)
+	buff, err = GetFile(context.Background(), "data:,hello%20world")
+	fmt.Println(string(buff), err)
+	// Output:
+	// key: value <nil>
+	// hello world <nil>
+}
+
+func ExampleGetFile_env() {
+	_ = os.Setenv("EXAMPLE_PROVISIONERS", "- uri: template://example")
+	defer os.Unsetenv("EXAMPLE_PROVISIONERS")
+	buff, err := GetFile(context.Background(), "env:EXAMPLE_PROVISIONERS")
+	fmt.Println(string(buff), err)
+	// Output: - uri: template://example <nil>
+}
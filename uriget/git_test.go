@@ -0,0 +1,155 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetFiles_GitRef(t *testing.T) {
+	repo := newTestGitRepo(t, map[string]string{"dir/a.yaml": "a1"})
+	first := repo.head(t)
+	repo.tag(t, "v1.0.0")
+	repo.git(t, repo.work, "push", "-q", repo.bare, first+":refs/heads/release")
+	repo.commit(t, map[string]string{"dir/a.yaml": "a2"})
+	second := repo.head(t)
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	for _, tt := range []struct {
+		name         string
+		query        string
+		wantContent  string
+		wantRevision string
+	}{
+		{"default", "", "a2", second},
+		{"tag", "?ref=v1.0.0", "a1", first},
+		{"full tag name", "?ref=refs/tags/v1.0.0", "a1", first},
+		{"branch", "?ref=release", "a1", first},
+		{"main", "?ref=main", "a2", second},
+		{"commit", "?ref=" + first, "a1", first},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := GetFiles(context.Background(), repo.uri+"/dir"+tt.query, logger)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(results) != 1 || results[0].URI != "a.yaml" {
+				t.Fatalf("unexpected results: %+v", results)
+			}
+			if string(results[0].Content) != tt.wantContent || results[0].Revision != tt.wantRevision {
+				t.Errorf("expected '%s' at %s, got '%s' at %s", tt.wantContent, tt.wantRevision, results[0].Content, results[0].Revision)
+			}
+
+			single, err := GetFiles(context.Background(), repo.uri+"/dir/a.yaml"+tt.query, logger)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(single) != 1 || string(single[0].Content) != tt.wantContent || single[0].Revision != tt.wantRevision || single[0].URI != repo.uri+"/dir/a.yaml"+tt.query {
+				t.Errorf("unexpected single file results: %+v", single)
+			}
+
+			buff, err := GetFile(context.Background(), repo.uri+"/dir/a.yaml"+tt.query, logger)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(buff) != tt.wantContent {
+				t.Errorf("expected '%s', got '%s'", tt.wantContent, buff)
+			}
+		})
+	}
+
+	_, err := GetFiles(context.Background(), repo.uri+"/dir?ref=v9", logger)
+	if err == nil || !strings.Contains(err.Error(), "failed to fetch") {
+		t.Errorf("expected fetch error for unknown ref, got %v", err)
+	}
+	_, err = GetFiles(context.Background(), repo.uri+"/dir?ref=--upload-pack=touch", logger)
+	if err == nil || err.Error() != "invalid git ref '--upload-pack=touch'" {
+		t.Errorf("expected invalid ref error, got %v", err)
+	}
+}
+
+func TestGetFiles_GitRefCache(t *testing.T) {
+	repo := newTestGitRepo(t, map[string]string{"a.yaml": "a1"})
+	first := repo.head(t)
+	repo.tag(t, "v1")
+	opts := []Option{WithCacheDir(t.TempDir()), WithLogger(log.New(os.Stderr, "", 0))}
+
+	for i := 0; i < 2; i++ {
+		results, err := GetFiles(context.Background(), repo.uri+"/a.yaml?ref=v1", opts...)
+		if err != nil {
+			t.Fatalf("fetch %d: unexpected error: %v", i, err)
+		}
+		if string(results[0].Content) != "a1" || results[0].Revision != first {
+			t.Errorf("fetch %d: unexpected results: %+v", i, results)
+		}
+	}
+
+	if _, err := GetFiles(context.Background(), repo.uri+"/a.yaml?ref="+first, opts...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	before := repo.requests.Load()
+	results, err := GetFiles(context.Background(), repo.uri+"/a.yaml?ref="+first, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(results[0].Content) != "a1" || results[0].Revision != first {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	if after := repo.requests.Load(); after != before {
+		t.Errorf("expected a commit pinned uri to be served from the cache, got %d requests", after-before)
+	}
+}
+
+func TestIsGitCommitSha(t *testing.T) {
+	for ref, expected := range map[string]bool{
+		"HEAD":   false,
+		"v1.0.0": false,
+		"0123456789abcdef0123456789abcdef01234567": true,
+		"0123456789ABCDEF0123456789ABCDEF01234567": false,
+		"0123456789abcdef":                         false,
+		strings.Repeat("a", 64):                    true,
+	} {
+		if actual := isGitCommitSha(ref); actual != expected {
+			t.Errorf("%s: expected %v, got %v", ref, expected, actual)
+		}
+	}
+}
+
+func TestGetFiles_GitTempDir(t *testing.T) {
+	repo := newTestGitRepo(t, map[string]string{"dir/a.yaml": "a"})
+	tempDir := t.TempDir()
+	buf := new(bytes.Buffer)
+
+	if _, err := GetFiles(context.Background(), repo.uri+"/dir?ref=main", WithTempDir(tempDir), WithLogger(log.New(buf, "", 0))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Initialized git remote in "+filepath.Join(tempDir, "score-go")) {
+		t.Errorf("expected the checkout to be in %s, got log: %s", tempDir, buf.String())
+	}
+	if entries, err := os.ReadDir(tempDir); err != nil || len(entries) != 0 {
+		t.Errorf("expected the checkout to be removed, got %v %v", entries, err)
+	}
+
+	_, err := GetFiles(context.Background(), repo.uri+"/dir?ref=main", WithTempDir(filepath.Join(tempDir, "missing")), WithLogger(log.New(buf, "", 0)))
+	if err == nil || err.Error() != "failed to make temp dir" {
+		t.Errorf("expected a temp dir error, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// testGitRepo is a git repository served over https by git http-backend so that the git schemes can be tested without
+// network access.
+type testGitRepo struct {
+	// uri is the git-https uri of the repository, without a trailing sub path.
+	uri      string
+	work     string
+	bare     string
+	requests atomic.Int32
+}
+
+func newTestGitRepo(t *testing.T, files map[string]string) *testGitRepo {
+	t.Helper()
+	execPath, err := exec.Command("git", "--exec-path").Output()
+	if err != nil {
+		t.Skipf("git is not available: %v", err)
+	}
+	backend := filepath.Join(strings.TrimSpace(string(execPath)), "git-http-backend")
+	if _, err := os.Stat(backend); err != nil {
+		t.Skipf("git http-backend is not available: %v", err)
+	}
+
+	root := t.TempDir()
+	r := &testGitRepo{work: filepath.Join(root, "work"), bare: filepath.Join(root, "srv", "repo.git")}
+	r.git(t, root, "init", "--initial-branch=main", r.work)
+	r.commit(t, files)
+	r.git(t, root, "clone", "--bare", r.work, r.bare)
+
+	handler := &cgi.Handler{
+		Path: backend,
+		Env:  []string{"GIT_PROJECT_ROOT=" + filepath.Join(root, "srv"), "GIT_HTTP_EXPORT_ALL=1"},
+	}
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.requests.Add(1)
+		handler.ServeHTTP(w, req)
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv("GIT_SSL_NO_VERIFY", "true")
+	r.uri = "git-https://" + srv.Listener.Addr().String() + "/repo.git"
+	return r
+}
+
+// commit writes the files into the working copy and commits them. An empty content removes the file. The commit is
+// pushed to the served repository once it exists.
+func (r *testGitRepo) commit(t *testing.T, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(r.work, name)
+		if content == "" {
+			if err := os.Remove(p); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r.git(t, r.work, "add", "-A")
+	r.git(t, r.work, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "update")
+	if _, err := os.Stat(r.bare); err == nil {
+		r.git(t, r.work, "push", "-q", r.bare, "main")
+	}
+}
+
+// tag creates an annotated tag at the current commit and pushes it to the served repository.
+func (r *testGitRepo) tag(t *testing.T, name string) {
+	t.Helper()
+	r.git(t, r.work, "-c", "user.name=test", "-c", "user.email=test@example.com", "tag", "-a", "-m", name, name)
+	r.git(t, r.work, "push", "-q", r.bare, "refs/tags/"+name)
+}
+
+// head returns the sha of the current commit.
+func (r *testGitRepo) head(t *testing.T) string {
+	t.Helper()
+	return r.git(t, r.work, "rev-parse", "HEAD")
+}
+
+func (r *testGitRepo) git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	c := exec.Command("git", args...)
+	c.Dir = dir
+	output, err := c.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s failed: %v: %s", strings.Join(args, " "), err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
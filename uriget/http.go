@@ -0,0 +1,359 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxHttpRetryDelay caps the delay between retries, including delays requested with a Retry-After header.
+const maxHttpRetryDelay = time.Minute
+
+// httpHeader is a header sent to a host. If env is set, the value is read from the environment variable when the
+// request is made and value is used as a prefix.
+type httpHeader struct {
+	name  string
+	value string
+	env   string
+}
+
+// WithHttpHeader sets a header on http requests to the host. The host is matched against the host:port of the url
+// first and then against the host name without the port. Headers are only sent to the matching host, including when
+// following redirects.
+func WithHttpHeader(host string, name string, value string) Option {
+	return func(o *options) {
+		o.addHttpHeader(host, httpHeader{name: name, value: value})
+	}
+}
+
+// WithHttpHeaderFromEnv is like WithHttpHeader but reads the value from the environment variable when the request is
+// made. The request fails if the variable is not set.
+func WithHttpHeaderFromEnv(host string, name string, envVar string) Option {
+	return func(o *options) {
+		o.addHttpHeader(host, httpHeader{name: name, env: envVar})
+	}
+}
+
+// WithHttpBasicAuth sets the username and password used for http requests to the host. See WithHttpHeader.
+func WithHttpBasicAuth(host string, username string, password string) Option {
+	return func(o *options) {
+		credential := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+		o.addHttpHeader(host, httpHeader{name: "Authorization", value: "Basic " + credential})
+	}
+}
+
+// WithHttpBearerToken sets the bearer token used for http requests to the host. See WithHttpHeader.
+func WithHttpBearerToken(host string, token string) Option {
+	return func(o *options) {
+		o.addHttpHeader(host, httpHeader{name: "Authorization", value: "Bearer " + token})
+	}
+}
+
+// WithHttpBearerTokenFromEnv is like WithHttpBearerToken but reads the token from the environment variable when the
+// request is made. The request fails if the variable is not set.
+func WithHttpBearerTokenFromEnv(host string, envVar string) Option {
+	return func(o *options) {
+		o.addHttpHeader(host, httpHeader{name: "Authorization", value: "Bearer ", env: envVar})
+	}
+}
+
+// WithNetrc enables basic authentication with the credentials from a netrc file for http requests to hosts that have
+// no Authorization header set by the other options. An empty path uses $NETRC or ~/.netrc, which are skipped if they
+// do not exist.
+func WithNetrc(path string) Option {
+	return func(o *options) {
+		o.netrc = true
+		o.netrcPath = path
+	}
+}
+
+// WithHttpMaxRedirects sets the number of redirects followed by the http scheme, 0 disables redirects. Redirects from
+// https to http are never followed. The CheckRedirect policy of an *http.Client set by WithHttpClient is also applied
+// to each redirect.
+func WithHttpMaxRedirects(n int) Option {
+	return func(o *options) {
+		o.httpMaxRedirects = n
+	}
+}
+
+// WithHttpAcceptedStatusCodes sets the http status codes whose response body is returned as the file content,
+// replacing the default of 200 only.
+func WithHttpAcceptedStatusCodes(codes ...int) Option {
+	return func(o *options) {
+		o.httpAcceptedStatusCodes = codes
+	}
+}
+
+// WithHttpRetries retries http requests that receive a 429 or 5xx status code up to the given number of times. The
+// delay doubles from the backoff after each attempt unless the response has a Retry-After header, and is capped at
+// one minute.
+func WithHttpRetries(retries int, backoff time.Duration) Option {
+	return func(o *options) {
+		o.httpRetries = retries
+		o.httpRetryBackoff = backoff
+	}
+}
+
+func (o *options) addHttpHeader(host string, header httpHeader) {
+	if o.httpHeaders == nil {
+		o.httpHeaders = make(map[string][]httpHeader)
+	}
+	host = strings.ToLower(host)
+	o.httpHeaders[host] = append(o.httpHeaders[host], header)
+}
+
+// doHttp sends a get request for the url and follows redirects up to the configured maximum. The prepare function is
+// called on each request before the host specific headers are set. If the http client set by WithHttpClient has a
+// CheckRedirect policy, it is called before each redirect is followed. The final request is returned with the
+// response.
+func (o *options) doHttp(ctx context.Context, u *url.URL, prepare func(req *http.Request)) (*http.Request, *http.Response, error) {
+	client := o.httpDoer()
+	var checkRedirect func(req *http.Request, via []*http.Request) error
+	if c, ok := o.httpClient.(*http.Client); ok {
+		checkRedirect = c.CheckRedirect
+	}
+	current := u
+	var via []*http.Request
+	for redirects := 0; ; redirects++ {
+		req, res, err := o.doHttpWithRetries(ctx, client, current, prepare)
+		if err != nil {
+			return nil, nil, err
+		}
+		location := res.Header.Get("Location")
+		if !isHttpRedirect(res.StatusCode) || location == "" {
+			return req, res, nil
+		}
+		via = append(via, req)
+		next, err := current.Parse(location)
+		if err != nil {
+			_ = res.Body.Close()
+			return nil, nil, fmt.Errorf("invalid redirect location '%s': %w", location, err)
+		}
+		if checkRedirect != nil {
+			nextReq, err := http.NewRequestWithContext(ctx, http.MethodGet, next.String(), nil)
+			if err != nil {
+				_ = res.Body.Close()
+				return nil, nil, fmt.Errorf("invalid redirect location '%s': %w", location, err)
+			}
+			if err := checkRedirect(nextReq, via); errors.Is(err, http.ErrUseLastResponse) {
+				return req, res, nil
+			} else if err != nil {
+				_ = res.Body.Close()
+				return nil, nil, fmt.Errorf("redirect from %s to %s refused: %w", current, next, err)
+			}
+		}
+		_ = res.Body.Close()
+		if redirects >= o.httpMaxRedirects {
+			return nil, nil, fmt.Errorf("%s %s stopped after %d redirects", req.Method, req.URL, o.httpMaxRedirects)
+		} else if current.Scheme == "https" && next.Scheme != "https" {
+			return nil, nil, fmt.Errorf("refusing to follow redirect from %s to %s", current, next)
+		}
+		o.logger.Printf("Following redirect from %s to %s", current, next)
+		current = next
+	}
+}
+
+// doHttpWithRetries sends a get request for the url, retrying on 429 and 5xx status codes.
+func (o *options) doHttpWithRetries(ctx context.Context, client HttpDoer, u *url.URL, prepare func(req *http.Request)) (*http.Request, *http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("bad url: %w", err)
+		}
+		prepare(req)
+		if err := o.setHttpHeaders(req); err != nil {
+			return nil, nil, err
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to make get request: %w", err)
+		} else if attempt >= o.httpRetries || (res.StatusCode != http.StatusTooManyRequests && res.StatusCode < http.StatusInternalServerError) {
+			return req, res, nil
+		}
+		delay := httpRetryDelay(res, o.httpRetryBackoff, attempt)
+		_ = res.Body.Close()
+		o.logger.Printf("Retrying %s %s in %s after status code %d", req.Method, req.URL, delay, res.StatusCode)
+		select {
+		case <-ctx.Done():
+			return nil, nil, fmt.Errorf("failed to make get request: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// httpDoer returns a copy of the http client with redirects disabled so that doHttp can apply the host specific
+// headers to each redirect. The redirect policy of the client is applied by doHttp instead. Any other HttpDoer is used
+// as is.
+func (o *options) httpDoer() HttpDoer {
+	if c, ok := o.httpClient.(*http.Client); ok {
+		copied := *c
+		copied.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+		return &copied
+	}
+	return o.httpClient
+}
+
+// setHttpHeaders sets the headers configured for the host of the request, and the netrc credentials if enabled.
+func (o *options) setHttpHeaders(req *http.Request) error {
+	for _, host := range []string{strings.ToLower(req.URL.Hostname()), strings.ToLower(req.URL.Host)} {
+		for _, h := range o.httpHeaders[host] {
+			value := h.value
+			if h.env != "" {
+				envValue := os.Getenv(h.env)
+				if envValue == "" {
+					return fmt.Errorf("environment variable %s for the %s header of %s is not set", h.env, h.name, host)
+				}
+				value += envValue
+			}
+			req.Header.Set(h.name, value)
+		}
+		if req.URL.Host == req.URL.Hostname() {
+			break
+		}
+	}
+	if o.netrc && req.Header.Get("Authorization") == "" {
+		login, password, ok, err := o.netrcCredentials(req.URL.Hostname())
+		if err != nil {
+			return err
+		} else if ok {
+			req.SetBasicAuth(login, password)
+		}
+	}
+	return nil
+}
+
+// acceptsHttpStatus returns true if the response body for the status code is returned as the file content.
+func (o *options) acceptsHttpStatus(code int) bool {
+	if len(o.httpAcceptedStatusCodes) == 0 {
+		return code == http.StatusOK
+	}
+	return slices.Contains(o.httpAcceptedStatusCodes, code)
+}
+
+func isHttpRedirect(code int) bool {
+	switch code {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// httpRetryDelay returns the delay before the next attempt from the Retry-After header of the response, or the
+// exponential backoff if there is none.
+func httpRetryDelay(res *http.Response, backoff time.Duration, attempt int) time.Duration {
+	delay := backoff << attempt
+	if retryAfter := res.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			delay = time.Duration(seconds) * time.Second
+		} else if t, err := http.ParseTime(retryAfter); err == nil {
+			delay = time.Until(t)
+		}
+	}
+	return max(0, min(delay, maxHttpRetryDelay))
+}
+
+// netrcCredentials returns the login and password for the host from the netrc file, falling back to the default
+// entry.
+func (o *options) netrcCredentials(host string) (string, string, bool, error) {
+	path := o.netrcPath
+	if path == "" {
+		if path = os.Getenv("NETRC"); path == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", "", false, nil
+			}
+			path = filepath.Join(home, ".netrc")
+		}
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if o.netrcPath == "" && errors.Is(err, fs.ErrNotExist) {
+			return "", "", false, nil
+		}
+		return "", "", false, fmt.Errorf("failed to read netrc file: %w", err)
+	}
+	login, password, ok := parseNetrc(raw, host)
+	return login, password, ok, nil
+}
+
+// parseNetrc returns the login and password of the machine entry for the host, or of the default entry if there is
+// no such machine. Macro definitions are skipped.
+func parseNetrc(raw []byte, host string) (string, string, bool) {
+	type entry struct {
+		login, password string
+	}
+	var machine, fallback *entry
+	var current *entry
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		for i := 0; i < len(fields); i++ {
+			switch fields[i] {
+			case "machine":
+				current = nil
+				if i+1 < len(fields) {
+					i++
+					if machine == nil && strings.EqualFold(fields[i], host) {
+						machine = &entry{}
+						current = machine
+					}
+				}
+			case "default":
+				current = nil
+				if fallback == nil {
+					fallback = &entry{}
+					current = fallback
+				}
+			case "login", "password", "account":
+				if i+1 < len(fields) {
+					i++
+					if current != nil && fields[i-1] == "login" {
+						current.login = fields[i]
+					} else if current != nil && fields[i-1] == "password" {
+						current.password = fields[i]
+					}
+				}
+			case "macdef":
+				current = nil
+				for scanner.Scan() && strings.TrimSpace(scanner.Text()) != "" {
+				}
+				i = len(fields)
+			}
+		}
+	}
+	if machine != nil {
+		return machine.login, machine.password, true
+	} else if fallback != nil {
+		return fallback.login, fallback.password, true
+	}
+	return "", "", false
+}
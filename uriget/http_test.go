@@ -0,0 +1,299 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTokenServer returns a server that requires the X-Token header to be "secret".
+func newTokenServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Token") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("content"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGetFile_HttpHeaders(t *testing.T) {
+	srv := newTokenServer(t)
+	u, _ := url.Parse(srv.URL)
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+	t.Setenv("TEST_URIGET_TOKEN", "secret")
+
+	for _, tt := range []struct {
+		name string
+		opt  Option
+	}{
+		{"host and port", WithHttpHeader(u.Host, "X-Token", "secret")},
+		{"host name", WithHttpHeader(u.Hostname(), "X-Token", "secret")},
+		{"environment", WithHttpHeaderFromEnv(u.Host, "X-Token", "TEST_URIGET_TOKEN")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			buff, err := GetFile(context.Background(), srv.URL, tt.opt, logger)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(buff) != "content" {
+				t.Errorf("unexpected content '%s'", buff)
+			}
+		})
+	}
+
+	_, err := GetFile(context.Background(), srv.URL, WithHttpHeader("other.example.com", "X-Token", "secret"), logger)
+	if err == nil || !strings.Contains(err.Error(), "non-200 status code: 401") {
+		t.Errorf("expected headers for other hosts to be ignored, got %v", err)
+	}
+	_, err = GetFile(context.Background(), srv.URL, WithHttpHeaderFromEnv(u.Host, "X-Token", "TEST_URIGET_UNSET"), logger)
+	if err == nil || err.Error() != "environment variable TEST_URIGET_UNSET for the X-Token header of "+u.Host+" is not set" {
+		t.Errorf("expected unset variable error, got %v", err)
+	}
+}
+
+func TestGetFile_HttpCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		_, _ = w.Write([]byte(r.Header.Get("Authorization") + " " + username + ":" + password))
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+	t.Setenv("TEST_URIGET_TOKEN", "from-env")
+	netrc := filepath.Join(t.TempDir(), "netrc")
+	if err := os.WriteFile(netrc, []byte("machine other.example.com login other password other\nmachine "+u.Hostname()+"\n  login netrc-user\n  password netrc-pass\n\ndefault login anonymous password anonymous\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{"basic", []Option{WithHttpBasicAuth(u.Host, "user", "pass")}, "Basic dXNlcjpwYXNz user:pass"},
+		{"bearer", []Option{WithHttpBearerToken(u.Host, "token")}, "Bearer token :"},
+		{"bearer from environment", []Option{WithHttpBearerTokenFromEnv(u.Host, "TEST_URIGET_TOKEN")}, "Bearer from-env :"},
+		{"netrc", []Option{WithNetrc(netrc)}, "Basic bmV0cmMtdXNlcjpuZXRyYy1wYXNz netrc-user:netrc-pass"},
+		{"explicit over netrc", []Option{WithNetrc(netrc), WithHttpBearerToken(u.Host, "token")}, "Bearer token :"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			buff, err := GetFile(context.Background(), srv.URL, append(tt.opts, logger)...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(buff) != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, buff)
+			}
+		})
+	}
+
+	_, err := GetFile(context.Background(), srv.URL, WithNetrc(filepath.Join(t.TempDir(), "missing")), logger)
+	if err == nil || !strings.HasPrefix(err.Error(), "failed to read netrc file:") {
+		t.Errorf("expected missing netrc error, got %v", err)
+	}
+	t.Setenv("NETRC", filepath.Join(t.TempDir(), "missing"))
+	if _, err := GetFile(context.Background(), srv.URL, WithNetrc(""), logger); err != nil {
+		t.Errorf("expected a missing default netrc file to be ignored, got %v", err)
+	}
+}
+
+func TestGetFile_HttpRedirects(t *testing.T) {
+	target := newTokenServer(t)
+	targetUrl, _ := url.Parse(target.URL)
+	var leaked atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked.Store(leaked.Load() || r.Header.Get("X-Token") == "secret" && r.URL.Path != "/start")
+		switch r.URL.Path {
+		case "/start":
+			http.Redirect(w, r, "/next", http.StatusFound)
+		case "/next":
+			http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+		}
+	}))
+	defer srv.Close()
+	srvUrl, _ := url.Parse(srv.URL)
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	// the origin header is only sent to the origin, the target header only to the target
+	buff, err := GetFile(context.Background(), srv.URL+"/start", WithHttpHeader(targetUrl.Host, "X-Token", "secret"), WithHttpHeader(srvUrl.Host, "X-Other", "other"), logger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(buff) != "content" {
+		t.Errorf("unexpected content '%s'", buff)
+	}
+	if leaked.Load() {
+		t.Errorf("expected the target header not to be sent to the origin")
+	}
+
+	_, err = GetFile(context.Background(), srv.URL+"/start", WithHttpMaxRedirects(1), logger)
+	if err == nil || err.Error() != "GET "+srv.URL+"/next stopped after 1 redirects" {
+		t.Errorf("expected redirect limit error, got %v", err)
+	}
+	_, err = GetFile(context.Background(), srv.URL+"/start", WithHttpMaxRedirects(0), logger)
+	if err == nil || err.Error() != "GET "+srv.URL+"/start stopped after 0 redirects" {
+		t.Errorf("expected redirects to be disabled, got %v", err)
+	}
+
+	downgrade := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusFound)
+	}))
+	defer downgrade.Close()
+	_, err = GetFile(context.Background(), downgrade.URL, WithHttpClient(downgrade.Client()), logger)
+	if err == nil || err.Error() != "refusing to follow redirect from "+downgrade.URL+" to "+target.URL {
+		t.Errorf("expected downgrade error, got %v", err)
+	}
+
+	// the redirect policy of the caller's client is applied to each hop
+	var checked []string
+	allowList := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		checked = append(checked, fmt.Sprintf("%s via %d", req.URL, len(via)))
+		if req.URL.Host != srvUrl.Host {
+			return fmt.Errorf("host %s is not allowed", req.URL.Host)
+		}
+		return nil
+	}}
+	_, err = GetFile(context.Background(), srv.URL+"/start", WithHttpClient(allowList), logger)
+	if err == nil || err.Error() != "redirect from "+srv.URL+"/next to "+target.URL+" refused: host "+targetUrl.Host+" is not allowed" {
+		t.Errorf("expected the redirect policy to refuse the target, got %v", err)
+	}
+	if expected := []string{srv.URL + "/next via 1", target.URL + " via 2"}; strings.Join(checked, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v to be checked, got %v", expected, checked)
+	}
+	lastResponse := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	_, err = GetFile(context.Background(), srv.URL+"/start", WithHttpClient(lastResponse), logger)
+	if err == nil || !strings.Contains(err.Error(), "302") {
+		t.Errorf("expected the redirect response to be returned, got %v", err)
+	}
+}
+
+func TestGetFile_HttpAcceptedStatusCodes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNonAuthoritativeInfo)
+		_, _ = w.Write([]byte("content"))
+	}))
+	defer srv.Close()
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	if _, err := GetFile(context.Background(), srv.URL, logger); err == nil || !strings.Contains(err.Error(), "non-200 status code: 203") {
+		t.Errorf("expected only 200 to be accepted by default, got %v", err)
+	}
+	buff, err := GetFile(context.Background(), srv.URL, WithHttpAcceptedStatusCodes(http.StatusOK, http.StatusNonAuthoritativeInfo), logger)
+	if err != nil || string(buff) != "content" {
+		t.Errorf("expected accepted content, got '%s' %v", buff, err)
+	}
+	if _, err := GetFile(context.Background(), srv.URL, WithHttpAcceptedStatusCodes(http.StatusOK), logger); err == nil || !strings.Contains(err.Error(), "unexpected status code: 203") {
+		t.Errorf("expected unexpected status error, got %v", err)
+	}
+}
+
+func TestGetFile_HttpRetries(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch requests.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte("content"))
+		}
+	}))
+	defer srv.Close()
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	if _, err := GetFile(context.Background(), srv.URL, logger); err == nil || !strings.Contains(err.Error(), "non-200 status code: 429") {
+		t.Errorf("expected no retries by default, got %v", err)
+	}
+	requests.Store(0)
+	buff, err := GetFile(context.Background(), srv.URL, WithHttpRetries(2, time.Millisecond), logger)
+	if err != nil || string(buff) != "content" {
+		t.Errorf("expected content after retries, got '%s' %v", buff, err)
+	}
+	requests.Store(0)
+	if _, err := GetFile(context.Background(), srv.URL, WithHttpRetries(1, time.Millisecond), logger); err == nil || !strings.Contains(err.Error(), "non-200 status code: 503") {
+		t.Errorf("expected the last status after exhausting retries, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	requests.Store(1)
+	if _, err := GetFile(ctx, srv.URL, WithHttpRetries(1, time.Hour), logger); err == nil {
+		t.Errorf("expected the cancelled context to stop retrying")
+	}
+}
+
+func TestHttpRetryDelay(t *testing.T) {
+	for _, tt := range []struct {
+		retryAfter string
+		attempt    int
+		expected   time.Duration
+	}{
+		{"", 0, time.Second},
+		{"", 2, 4 * time.Second},
+		{"", 10, maxHttpRetryDelay},
+		{"3", 0, 3 * time.Second},
+		{"3600", 0, maxHttpRetryDelay},
+		{"Mon, 01 Jan 2001 00:00:00 GMT", 0, 0},
+		{"invalid", 1, 2 * time.Second},
+	} {
+		res := &http.Response{Header: http.Header{}}
+		if tt.retryAfter != "" {
+			res.Header.Set("Retry-After", tt.retryAfter)
+		}
+		if actual := httpRetryDelay(res, time.Second, tt.attempt); actual != tt.expected {
+			t.Errorf("Retry-After '%s' attempt %d: expected %s, got %s", tt.retryAfter, tt.attempt, tt.expected, actual)
+		}
+	}
+}
+
+func TestParseNetrc(t *testing.T) {
+	raw := []byte("# comment\nmachine a.example.com login a password pa\nmacdef init\n  machine b.example.com login b password pb\n\nmachine c.example.com login c password pc\ndefault login d password pd\n")
+	for _, tt := range []struct {
+		host     string
+		expected string
+	}{
+		{"a.example.com", "a:pa"},
+		{"A.EXAMPLE.COM", "a:pa"},
+		{"b.example.com", "d:pd"},
+		{"c.example.com", "c:pc"},
+	} {
+		login, password, ok := parseNetrc(raw, tt.host)
+		if !ok || login+":"+password != tt.expected {
+			t.Errorf("%s: expected %s, got %s:%s %v", tt.host, tt.expected, login, password, ok)
+		}
+	}
+	if _, _, ok := parseNetrc([]byte("machine a.example.com login a password pa"), "b.example.com"); ok {
+		t.Errorf("expected no credentials without a default entry")
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// getData decodes the content of an RFC 2397 data uri such as data:,hello or data:text/yaml;base64,aGVsbG8=. The
+// media type is ignored and the data is percent decoded before any base64 decoding.
+func (o *options) getData(u *url.URL) ([]byte, error) {
+	if u.Opaque == "" {
+		return nil, fmt.Errorf("invalid data uri: expected data:[<mediatype>][;base64],<data>")
+	}
+	raw := u.Opaque
+	if u.RawQuery != "" {
+		raw += "?" + u.RawQuery
+	}
+	header, payload, ok := strings.Cut(raw, ",")
+	if !ok {
+		return nil, fmt.Errorf("invalid data uri: missing ',' before the data")
+	}
+	decoded, err := url.PathUnescape(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data uri: %w", err)
+	}
+	content := []byte(decoded)
+	if strings.HasSuffix(strings.ToLower(header), ";base64") {
+		if content, err = base64.StdEncoding.DecodeString(decoded); err != nil {
+			if content, err = base64.RawStdEncoding.DecodeString(decoded); err != nil {
+				return nil, fmt.Errorf("invalid data uri: failed to decode base64 data: %w", err)
+			}
+		}
+	}
+	if len(content) > o.limit {
+		return nil, limitExceeded(o.limit)
+	}
+	o.logger.Printf("Read %d bytes from data uri", len(content))
+	return content, nil
+}
+
+// getEnv returns the value of the environment variable named by an env:VARNAME or env://VARNAME uri. A variable that
+// is set to an empty value results in empty content.
+func (o *options) getEnv(u *url.URL) ([]byte, error) {
+	name := u.Opaque
+	if name == "" {
+		name = u.Host
+	}
+	if name == "" || u.Path != "" && u.Path != "/" {
+		return nil, fmt.Errorf("invalid env uri: expected env:VARNAME")
+	}
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, withSentinel(ErrNotFound, fmt.Errorf("environment variable %s is not set", name))
+	} else if len(value) > o.limit {
+		return nil, limitExceeded(o.limit)
+	}
+	o.logger.Printf("Read %d bytes from environment variable %s", len(value), name)
+	return []byte(value), nil
+}
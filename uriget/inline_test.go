@@ -0,0 +1,107 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+)
+
+func TestGetFile_Data(t *testing.T) {
+	for _, tt := range []struct {
+		uri      string
+		expected string
+	}{
+		{"data:,hello", "hello"},
+		{"data:,", ""},
+		{"data:,a%2Cb%20c?d=e", "a,b c?d=e"},
+		{"data:text/plain;charset=utf-8,hello", "hello"},
+		{"data:;base64,aGVsbG8=", "hello"},
+		{"data:;base64,aGVsbG8", "hello"},
+		{"DATA:text/yaml;BASE64,aGVsbG8=", "hello"},
+		{"data:application/octet-stream;base64,aGVs%0AbG8=", "hello"},
+		{"data:,hello?sha256=" + helloDigest[len("sha256:"):], "hello"},
+	} {
+		t.Run(tt.uri, func(t *testing.T) {
+			buff, err := GetFile(context.Background(), tt.uri, WithLogger(log.New(os.Stderr, "", 0)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(buff) != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, buff)
+			}
+		})
+	}
+}
+
+func TestGetFile_DataErrors(t *testing.T) {
+	for _, tt := range []struct {
+		uri      string
+		opts     []Option
+		expected string
+	}{
+		{"data:text/plain", nil, "invalid data uri: missing ',' before the data"},
+		{"data://host/path", nil, "invalid data uri: expected data:[<mediatype>][;base64],<data>"},
+		{"data:;base64,!!!", nil, "invalid data uri: failed to decode base64 data: illegal base64 data at input byte 0"},
+		{"data:,%zz", nil, "invalid data uri: invalid URL escape \"%zz\""},
+		{"data:,hello", []Option{WithLimit(4)}, "4 byte limit exceeded"},
+	} {
+		t.Run(tt.uri, func(t *testing.T) {
+			_, err := GetFile(context.Background(), tt.uri, append(tt.opts, WithLogger(log.New(os.Stderr, "", 0)))...)
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("expected error '%s', got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestGetFiles_Env(t *testing.T) {
+	t.Setenv("TEST_URIGET_CONTENT", "content")
+	t.Setenv("TEST_URIGET_EMPTY", "")
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	for _, uri := range []string{"env:TEST_URIGET_CONTENT", "env://TEST_URIGET_CONTENT"} {
+		results, err := GetFiles(context.Background(), uri, logger)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(results) != 1 || results[0].URI != uri || string(results[0].Content) != "content" {
+			t.Errorf("unexpected results: %+v", results)
+		}
+	}
+	if buff, err := GetFile(context.Background(), "env:TEST_URIGET_EMPTY", logger); err != nil || len(buff) != 0 {
+		t.Errorf("expected empty content, got '%s' %v", buff, err)
+	}
+
+	for _, tt := range []struct {
+		uri      string
+		opts     []Option
+		expected string
+	}{
+		{"env:TEST_URIGET_UNSET", nil, "environment variable TEST_URIGET_UNSET is not set"},
+		{"env:", nil, "invalid env uri: expected env:VARNAME"},
+		{"env://TEST_URIGET_CONTENT/path", nil, "invalid env uri: expected env:VARNAME"},
+		{"env:TEST_URIGET_CONTENT", []Option{WithLimit(3)}, "3 byte limit exceeded"},
+	} {
+		t.Run(tt.uri, func(t *testing.T) {
+			_, err := GetFiles(context.Background(), tt.uri, append(tt.opts, logger)...)
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("expected error '%s', got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// digestQueryParam is the uri query parameter used to pin the sha256 digest of the fetched content.
+const digestQueryParam = "sha256"
+
+// WithExpectedDigest sets the digest that the fetched content must match, in the form sha256:<hex>. This is equivalent
+// to the ?sha256=<hex> uri query parameter and can only be used when a single file is fetched.
+func WithExpectedDigest(digest string) Option {
+	return func(o *options) {
+		o.expectedDigest = digest
+	}
+}
+
+// expectedDigestFor returns the digest that the fetched content must match, or an empty string if there is none. The
+// digest query parameter is removed from the uri so that it is not sent to the remote.
+func (o *options) expectedDigestFor(u *url.URL) (string, error) {
+	var expected string
+	if o.expectedDigest != "" {
+		encoded, ok := strings.CutPrefix(o.expectedDigest, "sha256:")
+		if !ok {
+			return "", fmt.Errorf("invalid expected digest '%s': only sha256 digests are supported", o.expectedDigest)
+		}
+		var err error
+		if expected, err = normalizeSha256(encoded); err != nil {
+			return "", fmt.Errorf("invalid expected digest '%s': %w", o.expectedDigest, err)
+		}
+	}
+	query := u.Query()
+	if query.Has(digestQueryParam) {
+		fromQuery, err := normalizeSha256(query.Get(digestQueryParam))
+		if err != nil {
+			return "", fmt.Errorf("invalid %s query parameter: %w", digestQueryParam, err)
+		} else if expected != "" && expected != fromQuery {
+			return "", fmt.Errorf("%s query parameter conflicts with the expected digest option", digestQueryParam)
+		}
+		expected = fromQuery
+		u.RawQuery = removeQueryParam(u.RawQuery, digestQueryParam)
+	}
+	return expected, nil
+}
+
+// removeQueryParam removes the parameter from the raw query while leaving the encoding and order of the other
+// parameters untouched.
+func removeQueryParam(rawQuery string, name string) string {
+	parts := strings.Split(rawQuery, "&")
+	out := parts[:0]
+	for _, part := range parts {
+		key, _, _ := strings.Cut(part, "=")
+		if k, err := url.QueryUnescape(key); err != nil || k != name {
+			out = append(out, part)
+		}
+	}
+	return strings.Join(out, "&")
+}
+
+func normalizeSha256(encoded string) (string, error) {
+	encoded = strings.ToLower(encoded)
+	if raw, err := hex.DecodeString(encoded); err != nil || len(raw) != sha256.Size {
+		return "", fmt.Errorf("expected %d hex characters", sha256.Size*2)
+	}
+	return "sha256:" + encoded, nil
+}
+
+// verifyDigest checks the content against the expected digest if one is set.
+func verifyDigest(uri string, content []byte, expected string) error {
+	if expected == "" {
+		return nil
+	} else if actual := sha256Digest(content); actual != expected {
+		return fmt.Errorf("integrity check failed for %s: expected %s but got %s", uri, expected, actual)
+	}
+	return nil
+}
+
+// verifyDescriptor checks that the content matches the size and digest of the oci descriptor it was fetched for.
+func verifyDescriptor(desc v1.Descriptor, content []byte) error {
+	if err := desc.Digest.Validate(); err != nil {
+		return fmt.Errorf("invalid digest '%s': %w", desc.Digest, err)
+	} else if int64(len(content)) != desc.Size {
+		return fmt.Errorf("expected %d bytes but got %d", desc.Size, len(content))
+	} else if actual := desc.Digest.Algorithm().FromBytes(content); actual != desc.Digest {
+		return fmt.Errorf("expected digest %s but got %s", desc.Digest, actual)
+	}
+	return nil
+}
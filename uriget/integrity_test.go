@@ -0,0 +1,125 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+const helloDigest = "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func TestGetFiles_ExpectedDigest(t *testing.T) {
+	td := t.TempDir()
+	filePath := filepath.Join(td, "test.yaml")
+	if err := os.WriteFile(filePath, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(td, "other.yaml"), []byte("other"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+	wrongDigest := "sha256:" + strings.Repeat("0", 64)
+
+	for _, tt := range []struct {
+		name    string
+		uri     string
+		opts    []Option
+		wantErr string
+	}{
+		{"query", filePath + "?sha256=" + strings.TrimPrefix(helloDigest, "sha256:"), nil, ""},
+		{"query upper case", "file://" + filePath + "?sha256=" + strings.ToUpper(strings.TrimPrefix(helloDigest, "sha256:")), nil, ""},
+		{"option", filePath, []Option{WithExpectedDigest(helloDigest)}, ""},
+		{"query and option", filePath + "?sha256=" + strings.TrimPrefix(helloDigest, "sha256:"), []Option{WithExpectedDigest(helloDigest)}, ""},
+		{"mismatch", filePath + "?sha256=" + strings.Repeat("0", 64), nil, "integrity check failed for " + filePath + "?sha256=" + strings.Repeat("0", 64) + ": expected " + wrongDigest + " but got " + helloDigest},
+		{"option mismatch", filePath, []Option{WithExpectedDigest(wrongDigest)}, "integrity check failed for " + filePath + ": expected " + wrongDigest + " but got " + helloDigest},
+		{"conflict", filePath + "?sha256=" + strings.Repeat("0", 64), []Option{WithExpectedDigest(helloDigest)}, "sha256 query parameter conflicts with the expected digest option"},
+		{"invalid query", filePath + "?sha256=abc", nil, "invalid sha256 query parameter: expected 64 hex characters"},
+		{"invalid option", filePath, []Option{WithExpectedDigest("md5:abc")}, "invalid expected digest 'md5:abc': only sha256 digests are supported"},
+		{"directory", td + "?sha256=" + strings.TrimPrefix(helloDigest, "sha256:"), nil, "an expected digest requires a single file but " + td + "?sha256=" + strings.TrimPrefix(helloDigest, "sha256:") + " contains 2 files"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := GetFiles(context.Background(), tt.uri, append(tt.opts, logger)...)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("expected error '%s', got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(results) != 1 || string(results[0].Content) != "hello" {
+				t.Errorf("unexpected results: %+v", results)
+			}
+		})
+	}
+}
+
+func TestGetFile_ExpectedDigestHttp(t *testing.T) {
+	var receivedQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedQuery = r.URL.RawQuery
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer srv.Close()
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	buff, err := GetFile(context.Background(), srv.URL+"/file.yaml?b=2&sha256="+strings.TrimPrefix(helloDigest, "sha256:")+"&a=1", logger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(buff) != "hello" {
+		t.Errorf("expected content 'hello', got '%s'", buff)
+	}
+	if receivedQuery != "b=2&a=1" {
+		t.Errorf("expected the digest to be removed from the request query, got '%s'", receivedQuery)
+	}
+
+	_, err = GetFile(context.Background(), srv.URL+"/file.yaml", WithExpectedDigest("sha256:"+strings.Repeat("a", 64)), logger)
+	if err == nil || !strings.Contains(err.Error(), "integrity check failed for "+srv.URL+"/file.yaml") {
+		t.Errorf("expected integrity error, got %v", err)
+	}
+}
+
+func TestGetFile_OciLayerVerification(t *testing.T) {
+	reg := newTestRegistry(t)
+	reg.push(t, "provisioners", "v1", FileContent{URI: "a.provisioners.yaml", Content: []byte("hello")})
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+	uri := "oci://" + reg.host + "/provisioners:v1"
+
+	buff, err := GetFile(context.Background(), uri+"?sha256="+strings.TrimPrefix(helloDigest, "sha256:"), logger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(buff) != "hello" {
+		t.Errorf("expected content 'hello', got '%s'", buff)
+	}
+
+	reg.setBlob(digest.Digest(helloDigest), []byte("jello"))
+	_, err = GetFile(context.Background(), uri, logger)
+	expected := "layer 'a.provisioners.yaml' verification failed: expected digest " + helloDigest + " but got " + sha256Digest([]byte("jello"))
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error '%s', got %v", expected, err)
+	}
+}
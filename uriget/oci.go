@@ -0,0 +1,300 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// ociArchiveMediaTypes are the layer media types that are unpacked when an artifact consists of a single layer.
+var ociArchiveMediaTypes = map[string]bool{
+	v1.MediaTypeImageLayer:     true,
+	v1.MediaTypeImageLayerGzip: true,
+	"application/x-tar":        true,
+}
+
+func (o *options) getOci(ctx context.Context, u *url.URL) ([]byte, error) {
+	ref, err := parseOciReference(u)
+	if err != nil {
+		return nil, err
+	}
+	specifiedFile := strings.TrimPrefix(u.Fragment, "#")
+	files, err := o.fetchCached("oci", u.String(), func(previous *cacheEntry) (*fetchResult, error) {
+		return o.fetchOci(ctx, ref, specifiedFile, previous)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files[0].Content, nil
+}
+
+// getOciFiles is like getOci but returns all files in the artifact. The files are the layers with a title annotation,
+// or the regular files in the archive if the artifact consists of a single tar(.gz) layer. A fragment selects the
+// files whose path matches it as a glob pattern, and the include and exclude patterns apply like they do for
+// directories. The URI of each file is its path within the artifact, unless the fragment names a single file in which
+// case the original uri is kept.
+func (o *options) getOciFiles(ctx context.Context, u *url.URL) ([]FileContent, error) {
+	rawUri := u.String()
+	ref, err := parseOciReference(u)
+	if err != nil {
+		return nil, err
+	}
+	files, err := o.fetchCached("oci-files"+o.dirKey(), rawUri, func(previous *cacheEntry) (*fetchResult, error) {
+		return o.fetchOciFiles(ctx, ref, u.Fragment, previous)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if u.Fragment != "" && !strings.ContainsAny(u.Fragment, "*?[") {
+		files[0].URI = rawUri
+	}
+	return files, nil
+}
+
+func parseOciReference(u *url.URL) (registry.Reference, error) {
+	ref, err := registry.ParseReference(u.Host + u.Path)
+	if err != nil {
+		return registry.Reference{}, fmt.Errorf("invalid artifact URL: %w", err)
+	}
+	if ref.Reference == "" {
+		ref.Reference = "latest"
+	}
+	return ref, nil
+}
+
+// fetchOci pulls the selected file from the artifact. If a previous cache entry is given, the reference is resolved
+// first and nothing is pulled when the manifest digest has not changed.
+func (o *options) fetchOci(ctx context.Context, ref registry.Reference, specifiedFile string, previous *cacheEntry) (*fetchResult, error) {
+	remoteRepo, err := o.ociRepository(ref)
+	if err != nil {
+		return nil, err
+	}
+	desc, manifest, err := o.fetchOciManifest(ctx, remoteRepo, ref, previous)
+	if err != nil {
+		return nil, err
+	} else if manifest == nil {
+		return &fetchResult{notModified: true}, nil
+	}
+	var selectedLayer *v1.Descriptor
+	yamlFileCount := 0
+	for _, layer := range manifest.Layers {
+		title := layer.Annotations[v1.AnnotationTitle]
+		if !strings.HasSuffix(title, ".yaml") {
+			continue
+		} else if specifiedFile != "" {
+			if title == specifiedFile {
+				selectedLayer = &layer
+				break
+			}
+			continue
+		}
+		yamlFileCount++
+		if yamlFileCount > 1 {
+			return nil, withSentinel(ErrAmbiguousArtifact, fmt.Errorf("manifest contains %d .yaml files; specify a specific file in the URL fragment", yamlFileCount))
+		}
+		selectedLayer = &layer
+	}
+	if selectedLayer == nil {
+		return nil, withSentinel(ErrNotFound, fmt.Errorf("no matching .yaml file found in layers"))
+	}
+	buff, err := o.fetchOciBlob(ctx, remoteRepo, *selectedLayer)
+	if err != nil {
+		return nil, err
+	}
+	o.logger.Printf("Read %d bytes from %s", len(buff), specifiedFile)
+	return &fetchResult{
+		files:     []FileContent{{URI: ref.String(), Content: buff, Revision: desc.Digest.String()}},
+		resolved:  desc.Digest.String(),
+		immutable: ref.ValidateReferenceAsDigest() == nil,
+	}, nil
+}
+
+// fetchOciFiles pulls the files selected by the fragment and the include and exclude patterns from the artifact,
+// sorted by their path. See getOciFiles.
+func (o *options) fetchOciFiles(ctx context.Context, ref registry.Reference, fragment string, previous *cacheEntry) (*fetchResult, error) {
+	remoteRepo, err := o.ociRepository(ref)
+	if err != nil {
+		return nil, err
+	}
+	desc, manifest, err := o.fetchOciManifest(ctx, remoteRepo, ref, previous)
+	if err != nil {
+		return nil, err
+	} else if manifest == nil {
+		return &fetchResult{notModified: true}, nil
+	}
+
+	var files []FileContent
+	if len(manifest.Layers) == 1 && isOciArchive(manifest.Layers[0]) {
+		buff, err := o.fetchOciBlob(ctx, remoteRepo, manifest.Layers[0])
+		if err != nil {
+			return nil, err
+		}
+		unpacked, err := o.unpackArchive(buff, manifest.Layers[0].Annotations[v1.AnnotationTitle])
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range unpacked {
+			if o.ociSelects(f.URI, fragment) {
+				files = append(files, f)
+			}
+		}
+	} else {
+		for _, layer := range manifest.Layers {
+			title := layer.Annotations[v1.AnnotationTitle]
+			if title == "" || !o.ociSelects(title, fragment) {
+				continue
+			}
+			buff, err := o.fetchOciBlob(ctx, remoteRepo, layer)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, FileContent{URI: title, Content: buff})
+		}
+	}
+	if len(files) == 0 {
+		if fragment != "" {
+			return nil, withSentinel(ErrNotFound, fmt.Errorf("no files matching '%s' found in artifact", fragment))
+		}
+		return nil, withSentinel(ErrNotFound, fmt.Errorf("artifact contains no files"))
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].URI < files[j].URI
+	})
+	for i := range files {
+		files[i].Revision = desc.Digest.String()
+		o.logger.Printf("Read %d bytes from %s in %s", len(files[i].Content), files[i].URI, ref)
+	}
+	return &fetchResult{files: files, resolved: desc.Digest.String(), immutable: ref.ValidateReferenceAsDigest() == nil}, nil
+}
+
+// ociSelects reports whether the file path in the artifact is selected by the fragment glob and the include and
+// exclude patterns.
+func (o *options) ociSelects(name string, fragment string) bool {
+	if fragment != "" && !matchGlob(fragment, name) {
+		return false
+	}
+	return (len(o.include) == 0 || matchesAnyGlob(o.include, name)) && !matchesAnyGlob(o.exclude, name)
+}
+
+// fetchOciManifest fetches and verifies the image manifest for the reference. If a previous cache entry is given, the
+// reference is resolved first and a nil manifest is returned when the digest has not changed.
+func (o *options) fetchOciManifest(ctx context.Context, remoteRepo *remote.Repository, ref registry.Reference, previous *cacheEntry) (v1.Descriptor, *v1.Manifest, error) {
+	if previous != nil && previous.Resolved != "" {
+		desc, err := remoteRepo.Resolve(ctx, ref.Reference)
+		if err != nil {
+			return v1.Descriptor{}, nil, ociError(fmt.Errorf("manifest resolve failed: %w", err))
+		} else if desc.Digest.String() == previous.Resolved {
+			return desc, nil, nil
+		}
+	}
+	desc, rc, err := remoteRepo.Manifests().FetchReference(ctx, ref.Reference)
+	if err != nil {
+		return v1.Descriptor{}, nil, ociError(fmt.Errorf("manifest fetch failed: %w", err))
+	}
+	defer rc.Close()
+	rawManifest, err := readLimited(rc, o.limit)
+	if err != nil {
+		return v1.Descriptor{}, nil, fmt.Errorf("manifest read failed: %w", err)
+	} else if err := verifyDescriptor(desc, rawManifest); err != nil {
+		return v1.Descriptor{}, nil, fmt.Errorf("manifest verification failed: %w", err)
+	}
+	var manifest v1.Manifest
+	if err := json.Unmarshal(rawManifest, &manifest); err != nil {
+		return v1.Descriptor{}, nil, fmt.Errorf("manifest decode failed: %w", err)
+	}
+	return desc, &manifest, nil
+}
+
+// fetchOciBlob fetches the content of the layer and verifies it against the layer digest.
+func (o *options) fetchOciBlob(ctx context.Context, remoteRepo *remote.Repository, layer v1.Descriptor) ([]byte, error) {
+	_, rc, err := remoteRepo.Blobs().FetchReference(ctx, layer.Digest.String())
+	if err != nil {
+		return nil, ociError(fmt.Errorf("blob fetch failed: %w", err))
+	}
+	defer rc.Close()
+	buff, err := readLimited(rc, o.limit)
+	if err != nil {
+		return nil, fmt.Errorf("blob read failed: %w", err)
+	} else if err := verifyDescriptor(layer, buff); err != nil {
+		return nil, fmt.Errorf("layer '%s' verification failed: %w", layer.Annotations[v1.AnnotationTitle], err)
+	}
+	return buff, nil
+}
+
+// isOciArchive returns true if the layer is a tar archive, optionally compressed with gzip.
+func isOciArchive(layer v1.Descriptor) bool {
+	title := layer.Annotations[v1.AnnotationTitle]
+	return ociArchiveMediaTypes[layer.MediaType] ||
+		strings.HasSuffix(title, ".tar") || strings.HasSuffix(title, ".tar.gz") || strings.HasSuffix(title, ".tgz")
+}
+
+// unpackArchive reads the regular files from a tar archive in memory. Gzip compression is detected from the content.
+// Directories pushed by the oras cli are archived under the layer title, so that prefix is removed from the paths.
+// The limit applies to the total size of the unpacked files.
+func (o *options) unpackArchive(buff []byte, title string) ([]FileContent, error) {
+	var r io.Reader = bytes.NewReader(buff)
+	if bytes.HasPrefix(buff, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress archive: %w", err)
+		}
+		defer func() { _ = gz.Close() }()
+		r = gz
+	}
+	tr := tar.NewReader(r)
+	remaining := o.limit
+	var out []FileContent
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		} else if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(header.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("invalid file path '%s' in archive", header.Name)
+		}
+		if title != "" {
+			name = strings.TrimPrefix(name, title+"/")
+		}
+		content, err := io.ReadAll(io.LimitReader(tr, int64(remaining+1)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from archive: %w", name, err)
+		} else if len(content) > remaining {
+			return nil, fmt.Errorf("failed to unpack archive: %w", limitExceeded(o.limit))
+		}
+		remaining -= len(content)
+		out = append(out, FileContent{URI: name, Content: content})
+	}
+	return out, nil
+}
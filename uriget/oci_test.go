@@ -0,0 +1,207 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// testArchive returns a tar archive of the files, compressed with gzip if requested.
+func testArchive(t *testing.T, compress bool, files ...FileContent) []byte {
+	t.Helper()
+	buff := new(bytes.Buffer)
+	var w = tar.NewWriter(buff)
+	var gz *gzip.Writer
+	if compress {
+		gz = gzip.NewWriter(buff)
+		w = tar.NewWriter(gz)
+	}
+	for _, f := range files {
+		if strings.HasSuffix(f.URI, "/") {
+			if err := w.WriteHeader(&tar.Header{Name: f.URI, Typeflag: tar.TypeDir, Mode: 0755}); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if err := w.WriteHeader(&tar.Header{Name: f.URI, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(f.Content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(f.Content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.WriteHeader(&tar.Header{Name: "link.yaml", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buff.Bytes()
+}
+
+func TestGetFiles_OciArtifact(t *testing.T) {
+	reg := newTestRegistry(t)
+	manifestDigest := reg.pushLayers(t, "provisioners", "v1",
+		reg.layer("application/yaml", "20-b.provisioners.yaml", []byte("b")),
+		reg.layer("application/yaml", "10-a.provisioners.yaml", []byte("a")),
+		reg.layer("text/markdown", "README.md", []byte("readme")),
+		reg.layer("application/octet-stream", "", []byte("untitled")),
+	)
+	uri := "oci://" + reg.host + "/provisioners:v1"
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	for _, tt := range []struct {
+		name     string
+		uri      string
+		opts     []Option
+		expected []string
+	}{
+		{"all", uri, nil, []string{"10-a.provisioners.yaml", "20-b.provisioners.yaml", "README.md"}},
+		{"glob fragment", uri + "#*.provisioners.yaml", nil, []string{"10-a.provisioners.yaml", "20-b.provisioners.yaml"}},
+		{"exact fragment", uri + "#20-b.provisioners.yaml", nil, []string{uri + "#20-b.provisioners.yaml"}},
+		{"include and exclude", uri, []Option{WithInclude("*.yaml"), WithExclude("10-*")}, []string{"20-b.provisioners.yaml"}},
+		{"by digest", "oci://" + reg.host + "/provisioners@" + manifestDigest + "#README.md", nil, []string{"oci://" + reg.host + "/provisioners@" + manifestDigest + "#README.md"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := GetFiles(context.Background(), tt.uri, append(tt.opts, logger)...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := uris(results); strings.Join(actual, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+			for _, r := range results {
+				if r.Revision != manifestDigest {
+					t.Errorf("expected revision %s, got %s", manifestDigest, r.Revision)
+				}
+			}
+		})
+	}
+
+	_, err := GetFiles(context.Background(), uri+"#*.json", logger)
+	if err == nil || err.Error() != "no files matching '*.json' found in artifact" {
+		t.Errorf("expected no matching files error, got %v", err)
+	}
+	_, err = GetFile(context.Background(), uri, logger)
+	if err == nil || err.Error() != "manifest contains 2 .yaml files; specify a specific file in the URL fragment" {
+		t.Errorf("expected GetFile to require a fragment, got %v", err)
+	}
+}
+
+func TestGetFiles_OciArchive(t *testing.T) {
+	reg := newTestRegistry(t)
+	files := []FileContent{
+		{URI: "provisioners/"},
+		{URI: "provisioners/10-a.provisioners.yaml", Content: []byte("a")},
+		{URI: "provisioners/nested/20-b.provisioners.yaml", Content: []byte("b")},
+		{URI: "provisioners/notes.txt", Content: []byte("notes")},
+	}
+	reg.pushLayers(t, "bundle", "gzip", reg.layer(v1.MediaTypeImageLayerGzip, "provisioners", testArchive(t, true, files...)))
+	reg.pushLayers(t, "bundle", "tar", reg.layer("application/octet-stream", "bundle.tar", testArchive(t, false, files[1:]...)))
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	for _, tt := range []struct {
+		name     string
+		uri      string
+		expected []string
+	}{
+		{"oras directory", "oci://" + reg.host + "/bundle:gzip", []string{"10-a.provisioners.yaml", "nested/20-b.provisioners.yaml", "notes.txt"}},
+		{"glob fragment", "oci://" + reg.host + "/bundle:gzip#**/*.provisioners.yaml", []string{"10-a.provisioners.yaml", "nested/20-b.provisioners.yaml"}},
+		{"plain tar", "oci://" + reg.host + "/bundle:tar", []string{"provisioners/10-a.provisioners.yaml", "provisioners/nested/20-b.provisioners.yaml", "provisioners/notes.txt"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := GetFiles(context.Background(), tt.uri, logger)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := uris(results); strings.Join(actual, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+			if !strings.HasSuffix(results[0].URI, "10-a.provisioners.yaml") || string(results[0].Content) != "a" {
+				t.Errorf("unexpected first file: %+v", results[0])
+			}
+		})
+	}
+
+	large := bytes.Repeat([]byte("a"), 4096)
+	reg.pushLayers(t, "bundle", "large", reg.layer(v1.MediaTypeImageLayerGzip, "", testArchive(t, true, FileContent{URI: "a.yaml", Content: large}, FileContent{URI: "b.yaml", Content: large})))
+	_, err := GetFiles(context.Background(), "oci://"+reg.host+"/bundle:large", WithLimit(6000), logger)
+	if err == nil || err.Error() != "failed to unpack archive: 6000 byte limit exceeded" {
+		t.Errorf("expected limit error, got %v", err)
+	}
+
+	reg.pushLayers(t, "bundle", "escape", reg.layer(v1.MediaTypeImageLayer, "", testArchive(t, false, FileContent{URI: "../escape.yaml", Content: []byte("x")})))
+	_, err = GetFiles(context.Background(), "oci://"+reg.host+"/bundle:escape", logger)
+	if err == nil || err.Error() != "invalid file path '../escape.yaml' in archive" {
+		t.Errorf("expected invalid path error, got %v", err)
+	}
+}
+
+func TestGetFiles_OciCache(t *testing.T) {
+	reg := newTestRegistry(t)
+	first := reg.push(t, "provisioners", "latest", FileContent{URI: "a.yaml", Content: []byte("a1")})
+	opts := []Option{WithCacheDir(t.TempDir()), WithLogger(log.New(os.Stderr, "", 0))}
+	uri := "oci://" + reg.host + "/provisioners"
+
+	if _, err := GetFiles(context.Background(), uri, opts...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	before := reg.requestCount()
+	results, err := GetFiles(context.Background(), uri, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(results[0].Content) != "a1" || results[0].Revision != first {
+		t.Errorf("unexpected cached results: %+v", results)
+	}
+	if requests := reg.requestCount() - before; requests != 1 {
+		t.Errorf("expected revalidation to resolve the tag only, got %d requests", requests)
+	}
+
+	second := reg.push(t, "provisioners", "latest", FileContent{URI: "a.yaml", Content: []byte("a2")})
+	results, err = GetFiles(context.Background(), uri, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(results[0].Content) != "a2" || results[0].Revision != second {
+		t.Errorf("expected the updated tag to be fetched, got %+v", results)
+	}
+
+	if _, err := GetFiles(context.Background(), uri+"@"+first, opts...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	before = reg.requestCount()
+	results, err = GetFiles(context.Background(), uri+"@"+first, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(results[0].Content) != "a1" || reg.requestCount() != before {
+		t.Errorf("expected a digest reference to be served from the cache, got %+v after %d requests", results, reg.requestCount()-before)
+	}
+}
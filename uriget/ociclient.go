@@ -0,0 +1,181 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+	"oras.land/oras-go/v2/registry/remote/retry"
+)
+
+// WithOciCredentials sets the username and password used for the oci registry, given as host[:port]. Explicit
+// credentials take precedence over the credential store.
+func WithOciCredentials(registry string, username string, password string) Option {
+	return func(o *options) {
+		o.setOciCredential(registry, auth.Credential{Username: username, Password: password})
+	}
+}
+
+// WithOciToken sets a token that is sent as a bearer token to the oci registry, given as host[:port]. Explicit
+// credentials take precedence over the credential store.
+func WithOciToken(registry string, token string) Option {
+	return func(o *options) {
+		o.setOciCredential(registry, auth.Credential{AccessToken: token})
+	}
+}
+
+// WithOciCredentialStore sets the store that credentials for oci registries are read from, instead of the docker
+// config file and its credential helpers.
+func WithOciCredentialStore(store credentials.Store) Option {
+	return func(o *options) {
+		o.ociCredentialStore = store
+	}
+}
+
+// WithOciPlainHTTP sets the oci registries, given as host[:port], that are accessed over plain http instead of https.
+// Registries on localhost and 127.0.0.1 always use plain http.
+func WithOciPlainHTTP(registries ...string) Option {
+	return func(o *options) {
+		o.ociPlainHTTP = append(o.ociPlainHTTP, registries...)
+	}
+}
+
+// WithOciInsecure sets the oci registries, given as host[:port], whose tls certificate is not verified.
+func WithOciInsecure(registries ...string) Option {
+	return func(o *options) {
+		o.ociInsecure = append(o.ociInsecure, registries...)
+	}
+}
+
+// WithOciCABundle adds the PEM encoded certificates to the system roots used to verify the tls certificates of oci
+// registries.
+func WithOciCABundle(pem []byte) Option {
+	return func(o *options) {
+		o.ociCABundle = append(o.ociCABundle, pem...)
+	}
+}
+
+func (o *options) setOciCredential(registry string, cred auth.Credential) {
+	if o.ociCredentials == nil {
+		o.ociCredentials = make(map[string]auth.Credential)
+	}
+	o.ociCredentials[registry] = cred
+}
+
+// ociRepository returns a client for the repository of the reference. Requests are sent with the http client set by
+// WithHttpClient, retried on transient errors, and authenticated with the explicit credentials or the credential store.
+func (o *options) ociRepository(ref registry.Reference) (*remote.Repository, error) {
+	client, err := o.ociHttpClient(ref.Registry)
+	if err != nil {
+		return nil, err
+	}
+	remoteRepo, err := remote.NewRepository(ref.String())
+	if err != nil {
+		return nil, fmt.Errorf("connection to remote repository failed: %w", err)
+	}
+	remoteRepo.PlainHTTP = strings.HasPrefix(ref.Registry, "localhost") || strings.HasPrefix(ref.Registry, "127.0.0.1") ||
+		slices.Contains(o.ociPlainHTTP, ref.Registry)
+	remoteRepo.Client = &auth.Client{
+		Client:     client,
+		Cache:      auth.NewCache(),
+		Credential: o.ociCredential(),
+	}
+	return remoteRepo, nil
+}
+
+// ociCredential returns the function that resolves the credential for a registry.
+func (o *options) ociCredential() auth.CredentialFunc {
+	store := o.ociCredentialStore
+	if store == nil {
+		dockerStore, err := credentials.NewStoreFromDocker(credentials.StoreOptions{})
+		if err != nil {
+			o.logger.Printf("Warning: Unable to load Docker credentials, continuing without auth. Error: %v", err)
+		} else {
+			store = dockerStore
+		}
+	}
+	return func(ctx context.Context, hostport string) (auth.Credential, error) {
+		if cred, ok := o.ociCredentials[hostport]; ok {
+			return cred, nil
+		} else if cred, ok := o.ociCredentials["docker.io"]; ok && hostport == "registry-1.docker.io" {
+			return cred, nil
+		} else if store == nil {
+			return auth.EmptyCredential, nil
+		}
+		return credentials.Credential(store)(ctx, hostport)
+	}
+}
+
+// ociHttpClient returns the http client used for the registry. If the client set by WithHttpClient is an
+// *http.Client, a copy of it is used so that its transport applies; any other HttpDoer is used as the transport. The
+// overall timeout of the client is dropped since layer and archive transfers may take longer than a single request,
+// so oci transfers are bounded by the context instead. The tls options require the transport to be an *http.Transport.
+func (o *options) ociHttpClient(registryHost string) (*http.Client, error) {
+	client := &http.Client{}
+	var transport http.RoundTripper = httpDoerTransport{o.httpClient}
+	if c, ok := o.httpClient.(*http.Client); ok {
+		*client = *c
+		client.Timeout = 0
+		transport = c.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+	}
+
+	insecure := slices.Contains(o.ociInsecure, registryHost)
+	if insecure || len(o.ociCABundle) > 0 {
+		httpTransport, ok := transport.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("oci tls options require an http client with an *http.Transport")
+		}
+		httpTransport = httpTransport.Clone()
+		if httpTransport.TLSClientConfig == nil {
+			httpTransport.TLSClientConfig = &tls.Config{}
+		}
+		if len(o.ociCABundle) > 0 {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(o.ociCABundle) {
+				return nil, fmt.Errorf("no certificates found in the oci ca bundle")
+			}
+			httpTransport.TLSClientConfig.RootCAs = pool
+		}
+		httpTransport.TLSClientConfig.InsecureSkipVerify = insecure
+		transport = httpTransport
+	}
+	client.Transport = retry.NewTransport(transport)
+	return client, nil
+}
+
+// httpDoerTransport adapts an HttpDoer to an http.RoundTripper.
+type httpDoerTransport struct {
+	doer HttpDoer
+}
+
+func (t httpDoerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.doer.Do(req)
+}
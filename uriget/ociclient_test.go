@@ -0,0 +1,195 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+)
+
+// countingDoer is an HttpDoer that is not an *http.Client and counts the requests it sends.
+type countingDoer struct {
+	client   *http.Client
+	requests atomic.Int32
+}
+
+func (d *countingDoer) Do(req *http.Request) (*http.Response, error) {
+	d.requests.Add(1)
+	return d.client.Do(req)
+}
+
+func TestGetFiles_OciPlainHTTP(t *testing.T) {
+	reg := newTestRegistry(t)
+	reg.push(t, "provisioners", "v1", FileContent{URI: "a.yaml", Content: []byte("a")})
+	_, port, _ := net.SplitHostPort(reg.host)
+	registryHost := "registry.internal:" + port
+	uri := "oci://" + registryHost + "/provisioners:v1"
+	opts := []Option{WithHttpClient(reg.dialingClient()), WithLogger(log.New(os.Stderr, "", 0))}
+
+	if _, err := GetFiles(context.Background(), uri, opts...); err == nil || !strings.Contains(err.Error(), "server gave HTTP response to HTTPS client") {
+		t.Errorf("expected https to be used by default, got %v", err)
+	}
+	results, err := GetFiles(context.Background(), uri, append(opts, WithOciPlainHTTP(registryHost))...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || string(results[0].Content) != "a" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+}
+
+func TestGetFiles_OciTLS(t *testing.T) {
+	reg, caBundle := newTestTLSRegistry(t)
+	reg.push(t, "provisioners", "v1", FileContent{URI: "a.yaml", Content: []byte("a")})
+	_, port, _ := net.SplitHostPort(reg.host)
+	registryHost := "example.com:" + port
+	uri := "oci://" + registryHost + "/provisioners:v1"
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	_, err := GetFiles(context.Background(), uri, WithHttpClient(reg.dialingClient()), logger)
+	if err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Errorf("expected a certificate error, got %v", err)
+	}
+	for _, tt := range []struct {
+		name string
+		opt  Option
+	}{
+		{"ca bundle", WithOciCABundle(caBundle)},
+		{"insecure", WithOciInsecure(registryHost)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := GetFiles(context.Background(), uri, WithHttpClient(reg.dialingClient()), tt.opt, logger)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(results) != 1 || string(results[0].Content) != "a" {
+				t.Errorf("unexpected results: %+v", results)
+			}
+		})
+	}
+
+	_, err = GetFiles(context.Background(), uri, WithHttpClient(reg.dialingClient()), WithOciCABundle([]byte("not a certificate")), logger)
+	if err == nil || err.Error() != "no certificates found in the oci ca bundle" {
+		t.Errorf("expected invalid ca bundle error, got %v", err)
+	}
+	_, err = GetFiles(context.Background(), uri, WithHttpClient(&countingDoer{client: reg.dialingClient()}), WithOciInsecure(registryHost), logger)
+	if err == nil || err.Error() != "oci tls options require an http client with an *http.Transport" {
+		t.Errorf("expected tls options error, got %v", err)
+	}
+}
+
+func TestGetFiles_OciHttpDoer(t *testing.T) {
+	reg := newTestRegistry(t)
+	reg.push(t, "provisioners", "v1", FileContent{URI: "a.yaml", Content: []byte("a")})
+	doer := &countingDoer{client: http.DefaultClient}
+	if _, err := GetFiles(context.Background(), "oci://"+reg.host+"/provisioners:v1", WithHttpClient(doer), WithLogger(log.New(os.Stderr, "", 0))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if doer.requests.Load() == 0 || int(doer.requests.Load()) != reg.requestCount() {
+		t.Errorf("expected all %d registry requests to use the http client, got %d", reg.requestCount(), doer.requests.Load())
+	}
+}
+
+func TestGetFiles_OciIgnoresClientTimeout(t *testing.T) {
+	reg := newTestRegistry(t)
+	reg.push(t, "provisioners", "v1", FileContent{URI: "a.yaml", Content: []byte("a")})
+	client := &http.Client{Timeout: time.Nanosecond}
+	if _, err := GetFiles(context.Background(), "oci://"+reg.host+"/provisioners:v1", WithHttpClient(client), WithLogger(log.New(os.Stderr, "", 0))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.Timeout != time.Nanosecond {
+		t.Errorf("expected the client not to be modified")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := GetFiles(ctx, "oci://"+reg.host+"/provisioners:v1", WithHttpClient(client), WithLogger(log.New(os.Stderr, "", 0))); err == nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the context to bound the transfer, got %v", err)
+	}
+}
+
+func TestGetFiles_OciCredentials(t *testing.T) {
+	// avoid picking up the credentials of the current user
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	reg := newTestRegistry(t)
+	reg.push(t, "provisioners", "v1", FileContent{URI: "a.yaml", Content: []byte("a")})
+	reg.username, reg.password = "user", "secret"
+	uri := "oci://" + reg.host + "/provisioners:v1"
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	if _, err := GetFiles(context.Background(), uri, logger); err == nil || !strings.Contains(err.Error(), "basic credential not found") {
+		t.Errorf("expected an unauthorized error, got %v", err)
+	}
+	if _, err := GetFiles(context.Background(), uri, WithOciCredentials(reg.host, "user", "wrong"), logger); err == nil {
+		t.Errorf("expected an error with the wrong password")
+	}
+
+	store := credentials.NewMemoryStore()
+	if err := store.Put(context.Background(), reg.host, auth.Credential{Username: "user", Password: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name string
+		opts []Option
+	}{
+		{"explicit", []Option{WithOciCredentials(reg.host, "user", "secret")}},
+		{"store", []Option{WithOciCredentialStore(store)}},
+		{"explicit over store", []Option{WithOciCredentialStore(credentials.NewMemoryStore()), WithOciCredentials(reg.host, "user", "secret")}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := GetFiles(context.Background(), uri, append(tt.opts, logger)...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(results) != 1 || string(results[0].Content) != "a" {
+				t.Errorf("unexpected results: %+v", results)
+			}
+			if _, err := PutFiles(context.Background(), uri, results, append(tt.opts, logger)...); err != nil {
+				t.Errorf("unexpected push error: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetFiles_OciToken(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	reg := newTestRegistry(t)
+	reg.push(t, "provisioners", "v1", FileContent{URI: "a.yaml", Content: []byte("a")})
+	reg.token = "token"
+	uri := "oci://" + reg.host + "/provisioners:v1"
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+
+	if _, err := GetFiles(context.Background(), uri, logger); err == nil {
+		t.Errorf("expected an error without a token")
+	}
+	results, err := GetFiles(context.Background(), uri, WithOciToken(reg.host, "token"), logger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || string(results[0].Content) != "a" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+}
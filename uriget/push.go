@@ -0,0 +1,153 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// DefaultArtifactType is the artifact type of the manifests pushed by PutFiles unless WithArtifactType is used.
+const DefaultArtifactType = "application/vnd.score.files.v1"
+
+// WithArtifactType sets the artifact type of the manifest pushed by PutFiles.
+func WithArtifactType(artifactType string) Option {
+	return func(o *options) {
+		o.artifactType = artifactType
+	}
+}
+
+// PutFiles is the inverse of GetFiles for the oci scheme. It pushes the files to the registry as an artifact with one
+// layer per file, titled with the URI of the file, and tags it with the reference in the uri, which defaults to
+// "latest". The URI of each file must be a relative slash separated path so that GetFiles returns the same files.
+// Credentials are read from the same docker credential store that GetFiles uses. The digest of the pushed manifest is
+// returned so that the artifact can be pinned with oci://<registry>/<repository>@<digest>.
+func PutFiles(ctx context.Context, rawUri string, files []FileContent, optionFuncs ...Option) (string, error) {
+	u, err := url.Parse(rawUri)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse: %w", err)
+	}
+	opts := &options{}
+	for _, optionFunc := range append(defaultOptions, optionFuncs...) {
+		optionFunc(opts)
+	}
+	if strings.ToLower(u.Scheme) != "oci" {
+		return "", withSentinel(ErrUnsupportedScheme, fmt.Errorf("unsupported scheme '%s' for pushing files, only oci is supported", u.Scheme))
+	} else if u.Fragment != "" || u.RawQuery != "" {
+		return "", fmt.Errorf("an oci uri to push to cannot have a fragment or query")
+	} else if err := validatePutFiles(files); err != nil {
+		return "", err
+	}
+	ref, err := parseOciReference(u)
+	if err != nil {
+		return "", err
+	} else if ref.ValidateReferenceAsDigest() == nil {
+		return "", fmt.Errorf("cannot push to digest reference '%s', use a tag instead", ref.Reference)
+	}
+	remoteRepo, err := opts.ociRepository(ref)
+	if err != nil {
+		return "", err
+	}
+
+	manifest := v1.Manifest{
+		MediaType:    v1.MediaTypeImageManifest,
+		ArtifactType: opts.artifactType,
+		Config:       v1.DescriptorEmptyJSON,
+		Layers:       make([]v1.Descriptor, 0, len(files)),
+	}
+	manifest.SchemaVersion = 2
+	if err := pushOciBlob(ctx, remoteRepo, manifest.Config, manifest.Config.Data); err != nil {
+		return "", err
+	}
+	for _, f := range files {
+		layer := v1.Descriptor{
+			MediaType:   ociLayerMediaType(f.URI),
+			Digest:      digest.FromBytes(f.Content),
+			Size:        int64(len(f.Content)),
+			Annotations: map[string]string{v1.AnnotationTitle: f.URI},
+		}
+		if err := pushOciBlob(ctx, remoteRepo, layer, f.Content); err != nil {
+			return "", err
+		}
+		manifest.Layers = append(manifest.Layers, layer)
+	}
+
+	rawManifest, err := json.Marshal(manifest)
+	if err != nil {
+		return "", fmt.Errorf("manifest encode failed: %w", err)
+	}
+	desc := v1.Descriptor{
+		MediaType: manifest.MediaType,
+		Digest:    digest.FromBytes(rawManifest),
+		Size:      int64(len(rawManifest)),
+	}
+	if err := remoteRepo.PushReference(ctx, desc, bytes.NewReader(rawManifest), ref.Reference); err != nil {
+		return "", ociError(fmt.Errorf("manifest push failed: %w", err))
+	}
+	opts.logger.Printf("Pushed %d files to %s as %s", len(files), ref, desc.Digest)
+	return desc.Digest.String(), nil
+}
+
+// validatePutFiles returns an error unless there is at least one file and every URI is a unique, clean and relative
+// slash separated path.
+func validatePutFiles(files []FileContent) error {
+	if len(files) == 0 {
+		return fmt.Errorf("no files to push")
+	}
+	seen := make(map[string]bool, len(files))
+	for _, f := range files {
+		if f.URI == "" || f.URI == "." || path.IsAbs(f.URI) || path.Clean(f.URI) != f.URI || f.URI == ".." || strings.HasPrefix(f.URI, "../") {
+			return fmt.Errorf("invalid file path '%s': must be a clean relative path", f.URI)
+		} else if seen[f.URI] {
+			return fmt.Errorf("duplicate file path '%s'", f.URI)
+		}
+		seen[f.URI] = true
+	}
+	return nil
+}
+
+// ociLayerMediaType returns the media type of the layer for a file.
+func ociLayerMediaType(name string) string {
+	switch path.Ext(name) {
+	case ".yaml", ".yml":
+		return "application/yaml"
+	case ".json":
+		return "application/json"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// pushOciBlob uploads the content unless the registry already has a blob with the same digest.
+func pushOciBlob(ctx context.Context, remoteRepo *remote.Repository, desc v1.Descriptor, content []byte) error {
+	if exists, err := remoteRepo.Blobs().Exists(ctx, desc); err != nil {
+		return ociError(fmt.Errorf("blob check failed: %w", err))
+	} else if exists {
+		return nil
+	}
+	if err := remoteRepo.Blobs().Push(ctx, desc, bytes.NewReader(content)); err != nil {
+		return ociError(fmt.Errorf("blob push failed: %w", err))
+	}
+	return nil
+}
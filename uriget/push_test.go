@@ -0,0 +1,122 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestPutFiles_RoundTrip(t *testing.T) {
+	reg := newTestRegistry(t)
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+	files := []FileContent{
+		{URI: "20-b.provisioners.yaml", Content: []byte("b")},
+		{URI: "10-a.provisioners.yaml", Content: []byte("a")},
+		{URI: "nested/README.md", Content: []byte("readme")},
+	}
+
+	manifestDigest, err := PutFiles(context.Background(), "oci://"+reg.host+"/provisioners:v1", files, logger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var manifest v1.Manifest
+	if err := json.Unmarshal(reg.manifests[manifestDigest], &manifest); err != nil {
+		t.Fatalf("failed to decode pushed manifest: %v", err)
+	}
+	if manifest.ArtifactType != DefaultArtifactType || len(manifest.Layers) != 3 {
+		t.Errorf("unexpected manifest: %+v", manifest)
+	}
+	if manifest.Layers[0].MediaType != "application/yaml" || manifest.Layers[2].MediaType != "application/octet-stream" {
+		t.Errorf("unexpected layer media types: %+v", manifest.Layers)
+	}
+
+	for _, uri := range []string{"oci://" + reg.host + "/provisioners:v1", "oci://" + reg.host + "/provisioners@" + manifestDigest} {
+		results, err := GetFiles(context.Background(), uri, logger)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := "10-a.provisioners.yaml,20-b.provisioners.yaml,nested/README.md"
+		if actual := strings.Join(uris(results), ","); actual != expected {
+			t.Errorf("expected %s, got %s", expected, actual)
+		}
+		if string(results[2].Content) != "readme" || results[2].Revision != manifestDigest {
+			t.Errorf("unexpected file: %+v", results[2])
+		}
+	}
+
+	// pushing the same files again reuses the blobs and results in the same manifest
+	again, err := PutFiles(context.Background(), "oci://"+reg.host+"/provisioners", files, logger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if again != manifestDigest {
+		t.Errorf("expected the same manifest digest, got %s and %s", manifestDigest, again)
+	} else if reg.tags["provisioners:latest"] != manifestDigest {
+		t.Errorf("expected the latest tag to be set, got %v", reg.tags)
+	}
+}
+
+func TestPutFiles_ArtifactType(t *testing.T) {
+	reg := newTestRegistry(t)
+	manifestDigest, err := PutFiles(context.Background(), "oci://"+reg.host+"/provisioners:v1", []FileContent{{URI: "a.yaml", Content: []byte("a")}},
+		WithArtifactType("application/vnd.example.provisioners.v1"), WithLogger(log.New(os.Stderr, "", 0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var manifest v1.Manifest
+	if err := json.Unmarshal(reg.manifests[manifestDigest], &manifest); err != nil {
+		t.Fatalf("failed to decode pushed manifest: %v", err)
+	}
+	if manifest.ArtifactType != "application/vnd.example.provisioners.v1" {
+		t.Errorf("unexpected artifact type '%s'", manifest.ArtifactType)
+	}
+}
+
+func TestPutFiles_Errors(t *testing.T) {
+	reg := newTestRegistry(t)
+	uri := "oci://" + reg.host + "/provisioners"
+	one := []FileContent{{URI: "a.yaml", Content: []byte("a")}}
+	for _, tt := range []struct {
+		name     string
+		uri      string
+		files    []FileContent
+		expected string
+	}{
+		{"scheme", "https://example.com/a.yaml", one, "unsupported scheme 'https' for pushing files, only oci is supported"},
+		{"fragment", uri + "#a.yaml", one, "an oci uri to push to cannot have a fragment or query"},
+		{"digest", uri + "@" + helloDigest, one, "cannot push to digest reference '" + helloDigest + "', use a tag instead"},
+		{"no files", uri, nil, "no files to push"},
+		{"absolute path", uri, []FileContent{{URI: "/a.yaml"}}, "invalid file path '/a.yaml': must be a clean relative path"},
+		{"parent path", uri, []FileContent{{URI: "../a.yaml"}}, "invalid file path '../a.yaml': must be a clean relative path"},
+		{"unclean path", uri, []FileContent{{URI: "dir//a.yaml"}}, "invalid file path 'dir//a.yaml': must be a clean relative path"},
+		{"duplicate path", uri, append(one, one...), "duplicate file path 'a.yaml'"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := PutFiles(context.Background(), tt.uri, tt.files, WithLogger(log.New(os.Stderr, "", 0)))
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("expected error '%s', got %v", tt.expected, err)
+			}
+		})
+	}
+	if reg.requestCount() != 0 {
+		t.Errorf("expected invalid pushes to make no requests, got %d", reg.requestCount())
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// testRegistry is an in-memory stand-in for an OCI distribution registry, served on 127.0.0.1 so that oci uris use
+// plain http. Blobs are uploaded monolithically with a POST followed by a PUT.
+type testRegistry struct {
+	lock      sync.Mutex
+	host      string
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	tags      map[string]string
+	requests  int
+
+	// username and password or token are required for all requests when set.
+	username string
+	password string
+	token    string
+}
+
+func newTestRegistry(t *testing.T) *testRegistry {
+	t.Helper()
+	r := &testRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}, tags: map[string]string{}}
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	r.host = srv.Listener.Addr().String()
+	return r
+}
+
+// newTestTLSRegistry is like newTestRegistry but served over https with the httptest certificate, which is valid for
+// example.com. It returns the certificate in PEM format.
+func newTestTLSRegistry(t *testing.T) (*testRegistry, []byte) {
+	t.Helper()
+	r := &testRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}, tags: map[string]string{}}
+	srv := httptest.NewTLSServer(r)
+	t.Cleanup(srv.Close)
+	r.host = srv.Listener.Addr().String()
+	return r, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+}
+
+// dialingClient returns an http client that connects to the test registry regardless of the requested host, so that
+// the registry can be addressed by another host name.
+func (r *testRegistry) dialingClient() *http.Client {
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, r.host)
+		},
+	}}
+}
+
+// authorized returns true if the request carries the credentials required by the registry.
+func (r *testRegistry) authorized(req *http.Request) bool {
+	if r.token != "" {
+		return req.Header.Get("Authorization") == "Bearer "+r.token
+	} else if r.username != "" {
+		username, password, ok := req.BasicAuth()
+		return ok && username == r.username && password == r.password
+	}
+	return true
+}
+
+// push stores an artifact with one layer per file, titled with the file name, and tags it in the repository. It
+// returns the manifest digest.
+func (r *testRegistry) push(t *testing.T, repository string, tag string, files ...FileContent) string {
+	t.Helper()
+	layers := make([]v1.Descriptor, 0, len(files))
+	for _, f := range files {
+		layers = append(layers, r.layer("application/yaml", f.URI, f.Content))
+	}
+	return r.pushLayers(t, repository, tag, layers...)
+}
+
+// layer stores the content as a blob and returns its descriptor with the title annotation if one is given.
+func (r *testRegistry) layer(mediaType string, title string, content []byte) v1.Descriptor {
+	layer := r.putBlob(mediaType, content)
+	if title != "" {
+		layer.Annotations = map[string]string{v1.AnnotationTitle: title}
+	}
+	return layer
+}
+
+// pushLayers stores an artifact with the given layers and tags it in the repository. It returns the manifest digest.
+func (r *testRegistry) pushLayers(t *testing.T, repository string, tag string, layers ...v1.Descriptor) string {
+	t.Helper()
+	manifest := v1.Manifest{
+		MediaType:    v1.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.score.provisioners.v1",
+		Config:       r.putBlob(v1.DescriptorEmptyJSON.MediaType, v1.DescriptorEmptyJSON.Data),
+		Layers:       layers,
+	}
+	manifest.SchemaVersion = 2
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := digest.FromBytes(raw).String()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.manifests[d] = raw
+	r.tags[repository+":"+tag] = d
+	return d
+}
+
+func (r *testRegistry) putBlob(mediaType string, content []byte) v1.Descriptor {
+	d := digest.FromBytes(content)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.blobs[d.String()] = content
+	return v1.Descriptor{MediaType: mediaType, Digest: d, Size: int64(len(content))}
+}
+
+// setBlob replaces the content stored for a digest, simulating a registry that serves tampered content.
+func (r *testRegistry) setBlob(d digest.Digest, content []byte) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.blobs[d.String()] = content
+}
+
+// requestCount returns the number of requests served so far.
+func (r *testRegistry) requestCount() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.requests
+}
+
+func (r *testRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.requests++
+	if !r.authorized(req) {
+		if r.token != "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="https://auth.example.com/token",service="test"`)
+		} else {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+		}
+		http.Error(w, `{"errors":[{"code":"UNAUTHORIZED","message":"authentication required"}]}`, http.StatusUnauthorized)
+		return
+	}
+	if req.URL.Path == "/v2/" {
+		return
+	}
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	if i := strings.LastIndex(path, "/blobs/uploads/"); i > 0 && req.Method == http.MethodPost {
+		w.Header().Set("Location", req.URL.Path+"upload")
+		w.WriteHeader(http.StatusAccepted)
+	} else if i > 0 && req.Method == http.MethodPut {
+		raw, err := io.ReadAll(req.Body)
+		d := req.URL.Query().Get("digest")
+		if err != nil || digest.FromBytes(raw).String() != d {
+			http.Error(w, `{"errors":[{"code":"DIGEST_INVALID","message":"digest invalid"}]}`, http.StatusBadRequest)
+			return
+		}
+		r.blobs[d] = raw
+		w.WriteHeader(http.StatusCreated)
+	} else if i := strings.LastIndex(path, "/manifests/"); i > 0 && req.Method == http.MethodPut {
+		raw, err := io.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		d := digest.FromBytes(raw).String()
+		r.manifests[d] = raw
+		if reference := path[i+len("/manifests/"):]; reference != d {
+			r.tags[path[:i]+":"+reference] = d
+		}
+		w.Header().Set("Docker-Content-Digest", d)
+		w.WriteHeader(http.StatusCreated)
+	} else if i := strings.LastIndex(path, "/manifests/"); i > 0 && (req.Method == http.MethodGet || req.Method == http.MethodHead) {
+		reference := path[i+len("/manifests/"):]
+		d := reference
+		if !strings.HasPrefix(reference, "sha256:") {
+			d = r.tags[path[:i]+":"+reference]
+		}
+		raw, ok := r.manifests[d]
+		if !ok {
+			http.Error(w, `{"errors":[{"code":"MANIFEST_UNKNOWN","message":"manifest unknown"}]}`, http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", v1.MediaTypeImageManifest)
+		w.Header().Set("Docker-Content-Digest", d)
+		r.write(w, req, raw)
+	} else if i := strings.LastIndex(path, "/blobs/"); i > 0 && (req.Method == http.MethodGet || req.Method == http.MethodHead) {
+		raw, ok := r.blobs[path[i+len("/blobs/"):]]
+		if !ok {
+			http.Error(w, `{"errors":[{"code":"BLOB_UNKNOWN","message":"blob unknown"}]}`, http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		r.write(w, req, raw)
+	} else {
+		http.Error(w, `{"errors":[{"code":"UNSUPPORTED","message":"unsupported"}]}`, http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *testRegistry) write(w http.ResponseWriter, req *http.Request, raw []byte) {
+	w.Header().Set("Content-Length", strconv.Itoa(len(raw)))
+	if req.Method == http.MethodGet {
+		_, _ = w.Write(raw)
+	}
+}
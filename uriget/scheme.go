@@ -0,0 +1,117 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Getter retrieves the files for uris with a custom scheme. See RegisterScheme and WithScheme.
+type Getter interface {
+	// GetFiles returns the files for the uri. A uri that refers to a single file should return a single file with the
+	// uri as its URI, while the files of a directory or archive should use their relative path. Errors should wrap
+	// ErrNotFound, ErrUnauthorized or ErrLimitExceeded where they apply.
+	GetFiles(ctx context.Context, u *url.URL, opts GetterOptions) ([]FileContent, error)
+}
+
+// GetterFunc adapts a function to the Getter interface.
+type GetterFunc func(ctx context.Context, u *url.URL, opts GetterOptions) ([]FileContent, error)
+
+// GetFiles calls f.
+func (f GetterFunc) GetFiles(ctx context.Context, u *url.URL, opts GetterOptions) ([]FileContent, error) {
+	return f(ctx, u, opts)
+}
+
+// GetterOptions are the options of a GetFile or GetFiles call that are passed on to a Getter.
+type GetterOptions struct {
+	// Limit is the limit of bytes to read for each file. See WithLimit and ReadLimited.
+	Limit int
+	// Logger is used for informational messages. See WithLogger.
+	Logger *log.Logger
+	// HttpClient is the http client to use for remote requests. See WithHttpClient.
+	HttpClient HttpDoer
+	// TempDir is a temporary directory which may be used for storing buffers or temporary files. See WithTempDir.
+	TempDir string
+}
+
+// ReadLimited reads all bytes from the reader and returns an error if there are more than Limit bytes.
+func (o GetterOptions) ReadLimited(r io.Reader) ([]byte, error) {
+	return readLimited(r, o.Limit)
+}
+
+var (
+	registeredSchemesLock sync.RWMutex
+	registeredSchemes     = make(map[string]Getter)
+)
+
+// RegisterScheme makes the getter available for the scheme in all calls to GetFile and GetFiles. Schemes are case
+// insensitive, and registered getters take precedence over the built-in schemes. It is intended to be called from an
+// init function and panics if the getter is nil or the scheme is already registered.
+func RegisterScheme(scheme string, getter Getter) {
+	registeredSchemesLock.Lock()
+	defer registeredSchemesLock.Unlock()
+	scheme = strings.ToLower(scheme)
+	if getter == nil {
+		panic("uriget: RegisterScheme getter is nil")
+	} else if _, ok := registeredSchemes[scheme]; ok {
+		panic("uriget: RegisterScheme called twice for scheme " + scheme)
+	}
+	registeredSchemes[scheme] = getter
+}
+
+// WithScheme sets the getter for the scheme for a single call, taking precedence over registered getters and the
+// built-in schemes.
+func WithScheme(scheme string, getter Getter) Option {
+	return func(o *options) {
+		if o.schemes == nil {
+			o.schemes = make(map[string]Getter)
+		}
+		o.schemes[strings.ToLower(scheme)] = getter
+	}
+}
+
+// schemeGetter returns the custom getter for the scheme if there is one.
+func (o *options) schemeGetter(scheme string) (Getter, bool) {
+	scheme = strings.ToLower(scheme)
+	if getter, ok := o.schemes[scheme]; ok && getter != nil {
+		return getter, true
+	}
+	registeredSchemesLock.RLock()
+	defer registeredSchemesLock.RUnlock()
+	getter, ok := registeredSchemes[scheme]
+	return getter, ok
+}
+
+// getCustom retrieves the files for the uri from a custom getter. Content from custom getters is not cached.
+func (o *options) getCustom(ctx context.Context, getter Getter, u *url.URL) ([]FileContent, error) {
+	files, err := getter.GetFiles(ctx, u, GetterOptions{
+		Limit:      o.limit,
+		Logger:     o.logger,
+		HttpClient: o.httpClient,
+		TempDir:    o.tempDir,
+	})
+	if err != nil {
+		return nil, err
+	} else if len(files) == 0 {
+		return nil, withSentinel(ErrNotFound, fmt.Errorf("%s contains no files", u))
+	}
+	return files, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 The Score Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uriget
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+)
+
+// memoryGetter serves files from a map keyed by host, returning all files of the host for a uri without a path.
+type memoryGetter map[string][]FileContent
+
+func (g memoryGetter) GetFiles(ctx context.Context, u *url.URL, opts GetterOptions) ([]FileContent, error) {
+	files, ok := g[u.Host]
+	if !ok {
+		return nil, fmt.Errorf("bucket '%s' not found", u.Host)
+	}
+	if name := strings.TrimPrefix(u.Path, "/"); name != "" {
+		for _, f := range files {
+			if f.URI == name {
+				content, err := opts.ReadLimited(strings.NewReader(string(f.Content)))
+				if err != nil {
+					return nil, err
+				}
+				opts.Logger.Printf("Read %d bytes from %s", len(content), u)
+				return []FileContent{{URI: u.String(), Content: content}}, nil
+			}
+		}
+		return nil, fmt.Errorf("file '%s' not found", name)
+	}
+	return files, nil
+}
+
+var testBucket = memoryGetter{"bucket": {
+	{URI: "a.yaml", Content: []byte("a")},
+	{URI: "b.yaml", Content: []byte("bb")},
+}}
+
+func TestWithScheme(t *testing.T) {
+	logger := WithLogger(log.New(os.Stderr, "", 0))
+	opts := []Option{WithScheme("mem", testBucket), logger}
+
+	buff, err := GetFile(context.Background(), "MEM://bucket/b.yaml", opts...)
+	if err != nil || string(buff) != "bb" {
+		t.Errorf("expected 'bb', got '%s' %v", buff, err)
+	}
+	results, err := GetFiles(context.Background(), "mem://bucket", opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual := strings.Join(uris(results), ","); actual != "a.yaml,b.yaml" {
+		t.Errorf("unexpected files: %s", actual)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		uri      string
+		opts     []Option
+		expected string
+	}{
+		{"getter error", "mem://other/a.yaml", opts, "bucket 'other' not found"},
+		{"multiple files", "mem://bucket", opts, "mem://bucket contains 2 files, use GetFiles instead"},
+		{"limit", "mem://bucket/b.yaml", append(opts, WithLimit(1)), "1 byte limit exceeded"},
+		{"digest", "mem://bucket/a.yaml?sha256=" + strings.TrimPrefix(helloDigest, "sha256:"), opts, "integrity check failed for mem://bucket/a.yaml?sha256=" + strings.TrimPrefix(helloDigest, "sha256:")},
+		{"unregistered", "other://bucket/a.yaml", opts, "unsupported scheme 'other'"},
+		{"no files", "mem://empty", []Option{WithScheme("mem", memoryGetter{"empty": nil}), logger}, "mem://empty contains no files"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GetFile(context.Background(), tt.uri, tt.opts...)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.expected) {
+				t.Errorf("expected error '%s', got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestWithScheme_OverridesBuiltin(t *testing.T) {
+	getter := GetterFunc(func(ctx context.Context, u *url.URL, opts GetterOptions) ([]FileContent, error) {
+		return []FileContent{{URI: u.String(), Content: []byte(opts.TempDir)}}, nil
+	})
+	buff, err := GetFile(context.Background(), "https://example.com/a.yaml", WithScheme("https", getter), WithTempDir("/custom"), WithLogger(log.New(os.Stderr, "", 0)))
+	if err != nil || string(buff) != "/custom" {
+		t.Errorf("expected the custom getter to receive the temp dir, got '%s' %v", buff, err)
+	}
+}
+
+func TestRegisterScheme(t *testing.T) {
+	RegisterScheme("Test-Registered", testBucket)
+	t.Cleanup(func() {
+		registeredSchemesLock.Lock()
+		defer registeredSchemesLock.Unlock()
+		delete(registeredSchemes, "test-registered")
+	})
+
+	buff, err := GetFile(context.Background(), "test-registered://bucket/a.yaml", WithLogger(log.New(os.Stderr, "", 0)))
+	if err != nil || string(buff) != "a" {
+		t.Errorf("expected 'a', got '%s' %v", buff, err)
+	}
+	// an option takes precedence over the registered getter
+	_, err = GetFile(context.Background(), "test-registered://bucket/a.yaml", WithScheme("test-registered", memoryGetter{}), WithLogger(log.New(os.Stderr, "", 0)))
+	if err == nil || err.Error() != "bucket 'bucket' not found" {
+		t.Errorf("expected the option getter to be used, got %v", err)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		getter   Getter
+		expected string
+	}{
+		{"duplicate", testBucket, "uriget: RegisterScheme called twice for scheme test-registered"},
+		{"nil", nil, "uriget: RegisterScheme getter is nil"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tt.expected {
+					t.Errorf("expected panic '%s', got %v", tt.expected, r)
+				}
+			}()
+			RegisterScheme("test-registered", tt.getter)
+		})
+	}
+}
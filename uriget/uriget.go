@@ -20,7 +20,7 @@ package uriget
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -29,16 +29,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"sort"
 	"strings"
 	"time"
 
-	v1 "github.com/opencontainers/image-spec/specs-go/v1"
-	"oras.land/oras-go/v2/registry"
-	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
 	"oras.land/oras-go/v2/registry/remote/credentials"
-	"oras.land/oras-go/v2/registry/remote/retry"
 )
 
 // FileContent holds the URI and content of a file retrieved by GetFiles.
@@ -47,6 +42,9 @@ type FileContent struct {
 	URI string
 	// Content is the raw bytes of the file.
 	Content []byte
+	// Revision is the commit sha or manifest digest that the git and oci schemes resolved the uri to. It is empty for
+	// other schemes.
+	Revision string
 }
 
 // options is a struct holding fields that may need to have overrides in certain environments or during unit testing.
@@ -64,6 +62,54 @@ type options struct {
 
 	// tempDir is a temporary directory which may be used for storing buffers or temporary files.
 	tempDir string
+
+	// cacheDir is the directory used to cache fetched content, caching is disabled when empty. See WithCacheDir.
+	cacheDir string
+	// cacheTTL is how long a cache entry is used without revalidating it. See WithCacheTTL.
+	cacheTTL time.Duration
+	// offline restricts fetches to content in the cache. See WithOffline.
+	offline bool
+
+	// expectedDigest is the digest that the fetched content must match. See WithExpectedDigest.
+	expectedDigest string
+
+	// recursive enables reading nested directories. See WithRecursive.
+	recursive bool
+	// include and exclude are glob patterns that filter the files read from a directory. See WithInclude and
+	// WithExclude.
+	include []string
+	exclude []string
+
+	// artifactType is the artifact type of manifests pushed by PutFiles. See WithArtifactType.
+	artifactType string
+
+	// ociCredentials are the explicit credentials by oci registry. See WithOciCredentials and WithOciToken.
+	ociCredentials map[string]auth.Credential
+	// ociCredentialStore overrides the docker credential store. See WithOciCredentialStore.
+	ociCredentialStore credentials.Store
+	// ociPlainHTTP and ociInsecure are the oci registries accessed over http or without verifying tls certificates.
+	// See WithOciPlainHTTP and WithOciInsecure.
+	ociPlainHTTP []string
+	ociInsecure  []string
+	// ociCABundle holds PEM encoded certificates trusted for oci registries. See WithOciCABundle.
+	ociCABundle []byte
+
+	// httpHeaders are the headers sent to each lower case host. See WithHttpHeader.
+	httpHeaders map[string][]httpHeader
+	// netrc enables credentials from the netrc file at netrcPath. See WithNetrc.
+	netrc     bool
+	netrcPath string
+	// httpMaxRedirects is the number of redirects followed. See WithHttpMaxRedirects.
+	httpMaxRedirects int
+	// httpAcceptedStatusCodes are the accepted status codes, only 200 is accepted when empty. See
+	// WithHttpAcceptedStatusCodes.
+	httpAcceptedStatusCodes []int
+	// httpRetries and httpRetryBackoff control retrying failed http requests. See WithHttpRetries.
+	httpRetries      int
+	httpRetryBackoff time.Duration
+
+	// schemes are the getters for custom schemes by lower case scheme. See WithScheme.
+	schemes map[string]Getter
 }
 
 // HttpDoer is an http.Client interface used for overrides during testing or other http fetching implementations.
@@ -81,7 +127,10 @@ func WithLimit(b int) Option {
 	}
 }
 
-// WithHttpClient sets the http client that may be used.
+// WithHttpClient sets the http client that may be used. It is also used for requests to oci registries. The http
+// scheme follows redirects itself so that WithHttpHeader applies to each host, but calls the CheckRedirect policy of
+// an *http.Client before following each redirect. The Timeout of an *http.Client is not applied to oci transfers,
+// which are bounded by the context instead.
 func WithHttpClient(c HttpDoer) Option {
 	return func(o *options) {
 		o.httpClient = c
@@ -102,6 +151,34 @@ func WithLogger(l *log.Logger) Option {
 	}
 }
 
+// WithCacheDir enables an on-disk cache of content fetched with the http, git and oci schemes. A cached entry is
+// revalidated before it is used: http uses the ETag and Last-Modified response headers, git compares the remote HEAD
+// commit, and oci compares the manifest digest. If the remote is unreachable during revalidation, the cached content is
+// returned instead. Local files are never cached. Entries are keyed by the uri and by a hash of the configured http
+// headers, netrc and oci credentials, and accepted http status codes, so that content fetched with credentials is
+// not served to callers without them.
+func WithCacheDir(p string) Option {
+	return func(o *options) {
+		o.cacheDir = p
+	}
+}
+
+// WithCacheTTL sets how long a cache entry is used without revalidating it. A negative ttl means that entries never
+// expire. Entries for oci references by digest never expire since the content cannot change.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.cacheTTL = ttl
+	}
+}
+
+// WithOffline restricts fetches to content in the cache directory regardless of the age of the entry. Uris that are
+// not cached result in an error.
+func WithOffline(offline bool) Option {
+	return func(o *options) {
+		o.offline = offline
+	}
+}
+
 var defaultOptions = []Option{
 	WithLimit(1024 * 1024 * 1024),
 	WithLogger(log.Default()),
@@ -109,6 +186,8 @@ var defaultOptions = []Option{
 		Timeout: time.Second * 30,
 	}),
 	WithTempDir(os.TempDir()),
+	WithArtifactType(DefaultArtifactType),
+	WithHttpMaxRedirects(10),
 }
 
 const ()
@@ -118,8 +197,15 @@ const ()
 // Supported schemes:
 // - http/https: reads the file using http.
 // - file or no scheme: attempts to read the file from local file system.
-// - git-ssh / git-https: attempts to perform a sparse checkout of just the target file.
+// - git-ssh / git-https: attempts to perform a sparse checkout of just the target file. The ?ref= query parameter
+// selects a branch, tag, or full commit sha instead of the remote HEAD.
 // - oci: retrieves a file from a remote OCI registry based on the reference and optional fragment.
+// - data: decodes the inline content of an RFC 2397 data uri, for example data:;base64,aGVsbG8=.
+// - env: reads the content from the environment variable named by an env:VARNAME uri.
+//
+// Other schemes can be supported with RegisterScheme or WithScheme.
+//
+// For all schemes, a ?sha256=<hex> query parameter pins the digest of the content. See WithExpectedDigest.
 //
 // Deprecated: Use GetFiles instead, which supports both single files and directories.
 func GetFile(ctx context.Context, rawUri string, optionFuncs ...Option) ([]byte, error) {
@@ -131,25 +217,54 @@ func GetFile(ctx context.Context, rawUri string, optionFuncs ...Option) ([]byte,
 	for _, optionFunc := range append(defaultOptions, optionFuncs...) {
 		optionFunc(opts)
 	}
-	switch strings.ToLower(u.Scheme) {
-	case "http", "https":
-		return opts.getHttp(ctx, u)
-	case "file", "":
-		return opts.getFile(ctx, u)
-	case "git-ssh", "git-https":
-		return opts.getGit(ctx, u)
-	case "oci":
-		return opts.getOci(ctx, u)
-	default:
-		return nil, fmt.Errorf("unsupported scheme '%s'", u.Scheme)
+	expected, err := opts.expectedDigestFor(u)
+	if err != nil {
+		return nil, err
+	}
+	var content []byte
+	if getter, ok := opts.schemeGetter(u.Scheme); ok {
+		var files []FileContent
+		if files, err = opts.getCustom(ctx, getter, u); err == nil && len(files) != 1 {
+			return nil, withSentinel(ErrAmbiguousArtifact, fmt.Errorf("%s contains %d files, use GetFiles instead", rawUri, len(files)))
+		} else if err == nil {
+			content = files[0].Content
+		}
+	} else {
+		switch strings.ToLower(u.Scheme) {
+		case "http", "https":
+			content, err = opts.getHttp(ctx, u)
+		case "file", "":
+			content, err = opts.getFile(ctx, u)
+		case "git-ssh", "git-https":
+			content, err = opts.getGit(ctx, u)
+		case "oci":
+			content, err = opts.getOci(ctx, u)
+		case "data":
+			content, err = opts.getData(u)
+		case "env":
+			content, err = opts.getEnv(u)
+		default:
+			return nil, withSentinel(ErrUnsupportedScheme, fmt.Errorf("unsupported scheme '%s'", u.Scheme))
+		}
+	}
+	if err != nil {
+		return nil, err
+	} else if err := verifyDigest(rawUri, content, expected); err != nil {
+		return nil, err
 	}
+	return content, nil
 }
 
-// GetFiles is like GetFile but with support for importing multiple files from a directory. Currently, directory
-// support is implemented for the file and git schemes. For other schemes (http, oci), the target is treated as a
-// single file and returned as a single-element slice.
+// GetFiles is like GetFile but with support for importing multiple files from a directory. Directory support is
+// implemented for the file and git schemes, and for the oci scheme all files in the artifact are returned unless the
+// fragment names a single file. The fragment of an oci uri may also be a glob pattern such as #*.provisioners.yaml.
+// For the http, data and env schemes, the target is treated as a single file and returned as a single-element slice.
+// An expected digest can only be used when a single file is returned.
 //
-// TODO: Add directory support for oci scheme.
+// When the target is a single file, the URI of the file is the uri as given, for every scheme. When the target is a
+// directory or artifact, the URI of each file is its slash separated path relative to the directory or within the
+// artifact. Only the top-level files of a directory are read unless WithRecursive is set, and
+// WithInclude and WithExclude filter the files by glob patterns matched against the relative path.
 func GetFiles(ctx context.Context, rawUri string, optionFuncs ...Option) ([]FileContent, error) {
 	u, err := url.Parse(rawUri)
 	if err != nil {
@@ -159,23 +274,48 @@ func GetFiles(ctx context.Context, rawUri string, optionFuncs ...Option) ([]File
 	for _, optionFunc := range append(defaultOptions, optionFuncs...) {
 		optionFunc(opts)
 	}
+	expected, err := opts.expectedDigestFor(u)
+	if err != nil {
+		return nil, err
+	} else if err := opts.validateGlobs(); err != nil {
+		return nil, err
+	}
+	var files []FileContent
 	var content []byte
-	switch strings.ToLower(u.Scheme) {
-	case "file", "":
-		return opts.getFileOrDir(ctx, u)
-	case "http", "https":
-		content, err = opts.getHttp(ctx, u)
-	case "git-ssh", "git-https":
-		return opts.getGitFileOrDir(ctx, u)
-	case "oci":
-		content, err = opts.getOci(ctx, u)
-	default:
-		return nil, fmt.Errorf("unsupported scheme '%s'", u.Scheme)
+	if getter, ok := opts.schemeGetter(u.Scheme); ok {
+		files, err = opts.getCustom(ctx, getter, u)
+	} else {
+		switch strings.ToLower(u.Scheme) {
+		case "file", "":
+			files, err = opts.getFileOrDir(ctx, u)
+		case "http", "https":
+			content, err = opts.getHttp(ctx, u)
+		case "git-ssh", "git-https":
+			files, err = opts.getGitFileOrDir(ctx, u)
+		case "oci":
+			files, err = opts.getOciFiles(ctx, u)
+		case "data":
+			content, err = opts.getData(u)
+		case "env":
+			content, err = opts.getEnv(u)
+		default:
+			return nil, withSentinel(ErrUnsupportedScheme, fmt.Errorf("unsupported scheme '%s'", u.Scheme))
+		}
 	}
 	if err != nil {
 		return nil, err
 	}
-	return []FileContent{{URI: rawUri, Content: content}}, nil
+	if files == nil {
+		files = []FileContent{{URI: rawUri, Content: content}}
+	}
+	if expected != "" {
+		if len(files) != 1 {
+			return nil, withSentinel(ErrAmbiguousArtifact, fmt.Errorf("an expected digest requires a single file but %s contains %d files", rawUri, len(files)))
+		} else if err := verifyDigest(rawUri, files[0].Content, expected); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
 }
 
 func getStdinFile(ctx context.Context) ([]byte, error) {
@@ -202,32 +342,60 @@ func getStdinFile(ctx context.Context) ([]byte, error) {
 
 func readLimited(r io.Reader, limit int) ([]byte, error) {
 	if buff, err := io.ReadAll(io.LimitReader(r, int64(limit+1))); err == nil && len(buff) > limit {
-		return nil, fmt.Errorf("%d byte limit exceeded", limit)
+		return nil, limitExceeded(limit)
 	} else {
 		return buff, err
 	}
 }
 
 func (o *options) getHttp(ctx context.Context, u *url.URL) ([]byte, error) {
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	files, err := o.fetchCached("http", u.String(), func(previous *cacheEntry) (*fetchResult, error) {
+		return o.fetchHttp(ctx, u, previous)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("bad url: %w", err)
+		return nil, err
 	}
-	req = req.WithContext(ctx)
-	res, err := o.httpClient.Do(req)
+	return files[0].Content, nil
+}
+
+// fetchHttp performs the get request, the request is conditional if a previous cache entry is given.
+func (o *options) fetchHttp(ctx context.Context, u *url.URL, previous *cacheEntry) (*fetchResult, error) {
+	req, res, err := o.doHttp(ctx, u, func(req *http.Request) {
+		if previous != nil {
+			if previous.ETag != "" {
+				req.Header.Set("If-None-Match", previous.ETag)
+			}
+			if previous.LastModified != "" {
+				req.Header.Set("If-Modified-Since", previous.LastModified)
+			}
+		}
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to make get request: %w", err)
+		return nil, err
 	}
 	defer func() { _ = res.Body.Close() }()
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%s %s non-200 status code: %d", req.Method, req.URL, res.StatusCode)
+	if previous != nil && res.StatusCode == http.StatusNotModified {
+		return &fetchResult{notModified: true}, nil
+	} else if !o.acceptsHttpStatus(res.StatusCode) {
+		err := fmt.Errorf("%s %s non-200 status code: %d", req.Method, req.URL, res.StatusCode)
+		if len(o.httpAcceptedStatusCodes) > 0 {
+			err = fmt.Errorf("%s %s unexpected status code: %d", req.Method, req.URL, res.StatusCode)
+		}
+		if res.StatusCode >= http.StatusInternalServerError {
+			return nil, &unavailableError{err}
+		}
+		return nil, httpStatusError(res.StatusCode, err)
 	}
 	buff, err := readLimited(res.Body, o.limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 	o.logger.Printf("Read %d bytes from %s %s", len(buff), req.Method, req.URL)
-	return buff, nil
+	return &fetchResult{
+		files:        []FileContent{{URI: u.String(), Content: buff}},
+		etag:         res.Header.Get("ETag"),
+		lastModified: res.Header.Get("Last-Modified"),
+	}, nil
 }
 
 func (o *options) getFile(ctx context.Context, u *url.URL) ([]byte, error) {
@@ -246,7 +414,7 @@ func (o *options) getFile(ctx context.Context, u *url.URL) ([]byte, error) {
 	}
 	f, err := os.Open(targetPath)
 	if err != nil {
-		return nil, err
+		return nil, fileError(err)
 	}
 	defer func() { _ = f.Close() }()
 	buff, err := readLimited(f, o.limit)
@@ -257,8 +425,8 @@ func (o *options) getFile(ctx context.Context, u *url.URL) ([]byte, error) {
 	return buff, nil
 }
 
-// getFileOrDir resolves a file:// or bare path URI. If the path is a directory, it reads all files (non-recursively)
-// sorted by name. If it's a single file, it returns a single-element slice.
+// getFileOrDir resolves a file:// or bare path URI. If the path is a directory, it reads the files within it sorted by
+// their relative path, see readDir. If it's a single file, it returns a single-element slice.
 func (o *options) getFileOrDir(ctx context.Context, u *url.URL) ([]FileContent, error) {
 	targetPath := u.Host + u.Path
 	rawUri := u.String()
@@ -280,13 +448,13 @@ func (o *options) getFileOrDir(ctx context.Context, u *url.URL) ([]FileContent,
 
 	info, err := os.Stat(targetPath)
 	if err != nil {
-		return nil, err
+		return nil, fileError(err)
 	}
 
 	if !info.IsDir() {
 		f, err := os.Open(targetPath)
 		if err != nil {
-			return nil, err
+			return nil, fileError(err)
 		}
 		defer func() { _ = f.Close() }()
 		buff, err := readLimited(f, o.limit)
@@ -294,46 +462,18 @@ func (o *options) getFileOrDir(ctx context.Context, u *url.URL) ([]FileContent,
 			return nil, fmt.Errorf("failed to read file: %w", err)
 		}
 		o.logger.Printf("Read %d bytes from %s", len(buff), targetPath)
-		return []FileContent{{URI: targetPath, Content: buff}}, nil
-	}
-
-	entries, err := os.ReadDir(targetPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read directory: %w", err)
-	}
-
-	// Filter to skip sub-directories
-	var fileNames []string
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			fileNames = append(fileNames, entry.Name())
-		}
-	}
-	sort.Strings(fileNames)
-
-	if len(fileNames) == 0 {
-		return nil, fmt.Errorf("directory %s contains no files", targetPath)
+		return []FileContent{{URI: rawUri, Content: buff}}, nil
 	}
 
-	var out []FileContent
-	for _, name := range fileNames {
-		filePath := filepath.Join(targetPath, name)
-		f, err := os.Open(filePath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to open %s: %w", filePath, err)
-		}
-		buff, err := readLimited(f, o.limit)
-		_ = f.Close()
-		if err != nil {
-			return nil, fmt.Errorf("failed to read %s: %w", filePath, err)
-		}
-		o.logger.Printf("Read %d bytes from %s", len(buff), filePath)
-		out = append(out, FileContent{URI: filePath, Content: buff})
-	}
-	return out, nil
+	return o.readDir(targetPath, targetPath)
 }
 
 func (o *options) getGit(ctx context.Context, u *url.URL) ([]byte, error) {
+	originalUri := u.String()
+	ref, err := gitRef(u)
+	if err != nil {
+		return nil, err
+	}
 	u.Scheme = strings.TrimPrefix(u.Scheme, "git-")
 	u.RawQuery = ""
 	u.Fragment = ""
@@ -344,23 +484,125 @@ func (o *options) getGit(ctx context.Context, u *url.URL) ([]byte, error) {
 	u.Path = parts[0] + ".git"
 	subPath := parts[1]
 
-	td, err := o.gitSparseCheckout(ctx, u.String(), subPath)
+	files, err := o.fetchCached("git-file", originalUri, func(previous *cacheEntry) (*fetchResult, error) {
+		return o.fetchGit(ctx, u.String(), subPath, ref, previous, func(td string) ([]FileContent, error) {
+			f, err := os.Open(filepath.Join(td, subPath))
+			if err != nil {
+				return nil, fileError(err)
+			}
+			defer func() { _ = f.Close() }()
+			buff, err := readLimited(f, o.limit)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read file: %w", err)
+			}
+			o.logger.Printf("Read %d bytes from %s", len(buff), filepath.Join(td, subPath))
+			return []FileContent{{URI: originalUri, Content: buff}}, nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files[0].Content, nil
+}
+
+// fetchGit checks out the subPath at the ref from the remote and reads the files using the read function. If a
+// previous cache entry is given, the ref is resolved on the remote first and the checkout is skipped when it still
+// points to the same commit.
+func (o *options) fetchGit(ctx context.Context, remoteUrl string, subPath string, ref string, previous *cacheEntry, read func(td string) ([]FileContent, error)) (*fetchResult, error) {
+	if previous != nil && previous.Resolved != "" {
+		commit, err := o.gitLsRemote(ctx, remoteUrl, ref)
+		if err != nil {
+			return nil, err
+		} else if commit == previous.Resolved {
+			return &fetchResult{notModified: true}, nil
+		}
+	}
+
+	td, err := o.gitSparseCheckout(ctx, remoteUrl, subPath, ref)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = os.RemoveAll(td) }()
 
-	f, err := os.Open(filepath.Join(td, subPath))
+	commit, err := o.gitOutput(ctx, td, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve checked out commit: %w", err)
+	}
+	files, err := read(td)
 	if err != nil {
 		return nil, err
 	}
-	defer func() { _ = f.Close() }()
-	buff, err := readLimited(f, o.limit)
+	for i := range files {
+		files[i].Revision = commit
+	}
+	return &fetchResult{files: files, resolved: commit, immutable: isGitCommitSha(ref)}, nil
+}
+
+// gitLsRemote resolves the ref on the remote to a commit sha. Like git fetch, tags are preferred over branches with
+// the same name, and annotated tags are peeled to the commit they point to.
+func (o *options) gitLsRemote(ctx context.Context, remoteUrl string, ref string) (string, error) {
+	if isGitCommitSha(ref) {
+		return ref, nil
+	}
+	output, err := o.gitOutput(ctx, "", "ls-remote", remoteUrl, ref)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		err = fmt.Errorf("failed to resolve %s of %s: %w", ref, remoteUrl, err)
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && gitSentinel(exitErr.Stderr) != nil {
+			return "", withSentinel(gitSentinel(exitErr.Stderr), err)
+		}
+		return "", &unavailableError{err}
 	}
-	o.logger.Printf("Read %d bytes from %s", len(buff), filepath.Join(td, subPath))
-	return buff, nil
+	commits := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		if commit, name, ok := strings.Cut(line, "\t"); ok {
+			commits[name] = commit
+		}
+	}
+	for _, name := range []string{ref + "^{}", ref, "refs/tags/" + ref + "^{}", "refs/tags/" + ref, "refs/heads/" + ref} {
+		if commit, ok := commits[name]; ok {
+			return commit, nil
+		}
+	}
+	return "", withSentinel(ErrNotFound, fmt.Errorf("ref '%s' not found in %s", ref, remoteUrl))
+}
+
+// gitRef returns the branch, tag or commit sha from the ref query parameter of a git uri, defaulting to HEAD.
+func gitRef(u *url.URL) (string, error) {
+	ref := u.Query().Get("ref")
+	if ref == "" {
+		return "HEAD", nil
+	} else if strings.HasPrefix(ref, "-") || strings.ContainsFunc(ref, func(r rune) bool { return r <= ' ' || r == 0x7f }) {
+		return "", fmt.Errorf("invalid git ref '%s'", ref)
+	}
+	return ref, nil
+}
+
+// isGitCommitSha returns true if the ref is a full sha1 or sha256 commit hash, which can never point to other content.
+func isGitCommitSha(ref string) bool {
+	if len(ref) != 40 && len(ref) != 64 {
+		return false
+	}
+	return !strings.ContainsFunc(ref, func(r rune) bool { return (r < '0' || r > '9') && (r < 'a' || r > 'f') })
+}
+
+// gitOutput runs a git command in the given directory and returns its trimmed output.
+func (o *options) gitOutput(ctx context.Context, dir string, args ...string) (string, error) {
+	gitBinary, err := exec.LookPath("git")
+	if err != nil {
+		return "", fmt.Errorf("failed to find git binary on the local system: %w", err)
+	}
+	c := exec.CommandContext(ctx, gitBinary, args...)
+	c.Dir = dir
+	output, err := c.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			o.logger.Printf("command output: %s", exitErr.Stderr)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
 }
 
 // parseGitUrl parses a git URI and returns the remote URL and the sub-path within the repo.
@@ -378,10 +620,11 @@ func parseGitUrl(u *url.URL) (remoteUrl string, subPath string, err error) {
 	return u.String(), subPath, nil
 }
 
-// gitSparseCheckout performs a sparse checkout of the given subPath from the git remote into a temp directory.
-// The caller is responsible for cleaning up the returned temp directory.
-func (o *options) gitSparseCheckout(ctx context.Context, remoteUrl string, subPath string) (string, error) {
-	td, err := os.MkdirTemp(os.TempDir(), "score-go")
+// gitSparseCheckout performs a sparse checkout of the given subPath at the ref from the git remote into a temp
+// directory within the configured temp dir, see WithTempDir. The ref may be HEAD, a branch, a tag, or a full commit sha. The caller is responsible for cleaning up the
+// returned temp directory.
+func (o *options) gitSparseCheckout(ctx context.Context, remoteUrl string, subPath string, ref string) (string, error) {
+	td, err := os.MkdirTemp(o.tempDir, "score-go")
 	if err != nil {
 		return "", fmt.Errorf("failed to make temp dir")
 	} else if err := os.Chmod(td, 0700); err != nil {
@@ -402,14 +645,15 @@ func (o *options) gitSparseCheckout(ctx context.Context, remoteUrl string, subPa
 		{[]string{"init"}, "failed to init git repo in " + td},
 		{[]string{"remote", "add", "origin", remoteUrl}, "failed to set git remote to " + remoteUrl},
 		{[]string{"sparse-checkout", "set", "--no-cone", "--sparse-index", subPath}, "failed to set sparse checkout"},
-		{[]string{"pull", "origin", "HEAD", "--depth=1"}, "failed to fetch"},
+		{[]string{"fetch", "--depth=1", "origin", ref}, "failed to fetch"},
+		{[]string{"-c", "advice.detachedHead=false", "checkout", "--detach", "FETCH_HEAD"}, "failed to checkout " + ref},
 	} {
 		c := exec.CommandContext(ctx, gitBinary, step.args...)
 		c.Dir = td
 		if output, err := c.CombinedOutput(); err != nil {
 			o.logger.Printf("command output: %s", output)
 			_ = os.RemoveAll(td)
-			return "", fmt.Errorf("%s: %w", step.errMsg, err)
+			return "", withSentinel(gitSentinel(output), fmt.Errorf("%s: %w", step.errMsg, err))
 		}
 	}
 	o.logger.Printf("Initialized git remote in %s for %s", td, remoteUrl)
@@ -419,27 +663,35 @@ func (o *options) gitSparseCheckout(ctx context.Context, remoteUrl string, subPa
 // getGitFileOrDir is like getGit but returns multiple files if the subPath is a directory.
 func (o *options) getGitFileOrDir(ctx context.Context, u *url.URL) ([]FileContent, error) {
 	originalUri := u.String()
-	remoteUrl, subPath, err := parseGitUrl(u)
+	ref, err := gitRef(u)
 	if err != nil {
 		return nil, err
 	}
-
-	td, err := o.gitSparseCheckout(ctx, remoteUrl, subPath)
+	remoteUrl, subPath, err := parseGitUrl(u)
 	if err != nil {
 		return nil, err
 	}
-	defer func() { _ = os.RemoveAll(td) }()
 
+	return o.fetchCached("git"+o.dirKey(), originalUri, func(previous *cacheEntry) (*fetchResult, error) {
+		return o.fetchGit(ctx, remoteUrl, subPath, ref, previous, func(td string) ([]FileContent, error) {
+			return o.readGitFileOrDir(td, subPath, originalUri)
+		})
+	})
+}
+
+// readGitFileOrDir reads the subPath from a checkout in td. If the subPath is a directory, the files within it are read
+// like getFileOrDir.
+func (o *options) readGitFileOrDir(td string, subPath string, originalUri string) ([]FileContent, error) {
 	fullPath := filepath.Join(td, subPath)
 	info, err := os.Stat(fullPath)
 	if err != nil {
-		return nil, err
+		return nil, fileError(err)
 	}
 
 	if !info.IsDir() {
 		f, err := os.Open(fullPath)
 		if err != nil {
-			return nil, err
+			return nil, fileError(err)
 		}
 		defer func() { _ = f.Close() }()
 		buff, err := readLimited(f, o.limit)
@@ -450,101 +702,5 @@ func (o *options) getGitFileOrDir(ctx context.Context, u *url.URL) ([]FileConten
 		return []FileContent{{URI: originalUri, Content: buff}}, nil
 	}
 
-	entries, err := os.ReadDir(fullPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read directory: %w", err)
-	}
-
-	var fileNames []string
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			fileNames = append(fileNames, entry.Name())
-		}
-	}
-	sort.Strings(fileNames)
-
-	if len(fileNames) == 0 {
-		return nil, fmt.Errorf("directory %s contains no files", subPath)
-	}
-
-	var out []FileContent
-	for _, name := range fileNames {
-		filePath := filepath.Join(fullPath, name)
-		f, err := os.Open(filePath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to open %s: %w", filePath, err)
-		}
-		buff, err := readLimited(f, o.limit)
-		_ = f.Close()
-		if err != nil {
-			return nil, fmt.Errorf("failed to read %s: %w", filePath, err)
-		}
-		o.logger.Printf("Read %d bytes from %s", len(buff), filePath)
-		out = append(out, FileContent{URI: subPath + "/" + name, Content: buff})
-	}
-	return out, nil
-}
-
-func (o *options) getOci(ctx context.Context, u *url.URL) ([]byte, error) {
-	ref, err := registry.ParseReference(u.Host + u.Path)
-	if err != nil {
-		return nil, fmt.Errorf("invalid artifact URL: %w", err)
-	}
-	if ref.Reference == "" {
-		ref.Reference = "latest"
-	}
-	specifiedFile := strings.TrimPrefix(u.Fragment, "#")
-	storeOpts := credentials.StoreOptions{}
-	credStore, err := credentials.NewStoreFromDocker(storeOpts)
-	if err != nil {
-		o.logger.Printf("Warning: Unable to load Docker credentials, continuing without auth. Error: %v", err)
-	}
-	remoteRepo, err := remote.NewRepository(ref.String())
-	if err != nil {
-		return nil, fmt.Errorf("connection to remote repository failed: %w", err)
-	}
-	remoteRepo.PlainHTTP = strings.HasPrefix(ref.Registry, "localhost") || strings.HasPrefix(ref.Registry, "127.0.0.1")
-	remoteRepo.Client = &auth.Client{
-		Client:     retry.DefaultClient,
-		Cache:      auth.NewCache(),
-		Credential: credentials.Credential(credStore),
-	}
-	_, rc, err := remoteRepo.Manifests().FetchReference(ctx, ref.Reference)
-	if err != nil {
-		return nil, fmt.Errorf("manifest fetch failed: %w", err)
-	}
-	defer rc.Close()
-	var manifest v1.Manifest
-	if err := json.NewDecoder(rc).Decode(&manifest); err != nil {
-		return nil, fmt.Errorf("manifest decode failed: %w", err)
-	}
-	var selectedLayer *v1.Descriptor
-	yamlFileCount := 0
-	for _, layer := range manifest.Layers {
-		title := layer.Annotations[v1.AnnotationTitle]
-		if strings.HasSuffix(title, ".yaml") {
-			yamlFileCount++
-			if specifiedFile == "" && yamlFileCount > 1 {
-				return nil, fmt.Errorf("manifest contains %d .yaml files; specify a specific file in the URL fragment", yamlFileCount)
-			}
-			if specifiedFile == "" || title == specifiedFile {
-				selectedLayer = &layer
-				break
-			}
-		}
-	}
-	if selectedLayer == nil {
-		return nil, fmt.Errorf("no matching .yaml file found in layers")
-	}
-	_, rc, err = remoteRepo.Blobs().FetchReference(ctx, selectedLayer.Digest.String())
-	if err != nil {
-		return nil, fmt.Errorf("blob fetch failed: %w", err)
-	}
-	defer rc.Close()
-	buff, err := readLimited(rc, o.limit)
-	if err != nil {
-		return nil, fmt.Errorf("blob read failed: %w", err)
-	}
-	o.logger.Printf("Read %d bytes from %s", len(buff), specifiedFile)
-	return buff, nil
+	return o.readDir(fullPath, subPath)
 }